@@ -2,10 +2,48 @@
 package itertools
 
 import (
+	"bytes"
 	"cmp"
+	"container/list"
+	"context"
+	"io"
 	"iter"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 )
 
+// Integer is a constraint that permits any integer type.
+// It is used for count parameters, so that callers can pass counts of any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Numeric is a constraint that permits any integer or floating-point type.
+type Numeric interface {
+	Integer | Float
+}
+
+// Empty returns an iterator that yields no values.
+func Empty[V any]() iter.Seq[V] {
+	return func(yield func(V) bool) {}
+}
+
+// Once returns an iterator that yields v once.
+func Once[V any](v V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		yield(v)
+	}
+}
+
 // FromSlice returns an iterator yielding all the values from vs.
 func FromSlice[V any](vs []V) iter.Seq[V] {
 	return func(yield func(V) bool) {
@@ -17,6 +55,12 @@ func FromSlice[V any](vs []V) iter.Seq[V] {
 	}
 }
 
+// Of returns an iterator yielding all the values from vs.
+// It is a variadic form of FromSlice.
+func Of[V any](vs ...V) iter.Seq[V] {
+	return FromSlice(vs)
+}
+
 // FromMap returns an iterator yielding all the values from m.
 func FromMap[K comparable, V any](m map[K]V) iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
@@ -28,6 +72,120 @@ func FromMap[K comparable, V any](m map[K]V) iter.Seq2[K, V] {
 	}
 }
 
+// FromRunes returns an iterator yielding the runes decoded from the UTF-8 string s.
+// Invalid UTF-8 sequences are yielded as utf8.RuneError, one byte at a time.
+func FromRunes(s string) iter.Seq[rune] {
+	return func(yield func(rune) bool) {
+		for _, r := range s {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// FromBytes returns an iterator yielding all the bytes from bs.
+func FromBytes(bs []byte) iter.Seq[byte] {
+	return FromSlice(bs)
+}
+
+// FromChannel returns an iterator yielding all the values received from ch until it is closed.
+// If the consumer stops early, values already buffered in ch are not drained, and producers
+// blocked on sending to ch remain blocked: closing or draining ch is left to the caller.
+func FromChannel[V any](ch <-chan V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ToChannel returns a channel receiving all the values from seq, which is closed once seq is exhausted.
+// Values are sent from a separate goroutine, through a channel with the given buffer size.
+// That goroutine only exits once seq is exhausted: use ToChannelContext if the channel may be abandoned.
+func ToChannel[V any](seq iter.Seq[V], buffer int) <-chan V {
+	ch := make(chan V, buffer)
+	go func() {
+		defer close(ch)
+		for v := range seq {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+// ToChannelContext works like ToChannel, but stops sending values and closes the channel once ctx is done.
+// ctx is checked before sending each value: once ctx is done, no more values are pulled from seq, except for the one
+// seq may be producing at that time, which is dropped.
+func ToChannelContext[V any](ctx context.Context, seq iter.Seq[V], buffer int) <-chan V {
+	ch := make(chan V, buffer)
+	go func() {
+		defer close(ch)
+		for v := range seq {
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Buffer returns an iterator that will yield values from seq, which is run ahead of the consumer in a separate
+// goroutine, buffering up to size values. This decouples an irregular producer from a bursty consumer.
+// When the consumer stops early, the goroutine exits as soon as seq produces its next value.
+func Buffer[V any](seq iter.Seq[V], size int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		for v := range FromChannel(ToChannelContext(ctx, seq, size)) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// WithContext returns an iterator that will yield values from seq until ctx is done.
+// Cancellation is observed between values, as ctx.Err() is checked before yielding each of them:
+// a value that is being produced by seq when ctx is cancelled is dropped.
+func WithContext[V any](ctx context.Context, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range seq {
+			if ctx.Err() != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Interruptible returns an iterator that will yield values from seq until stop is closed or receives a value.
+// It works like WithContext, for code relying on a done channel: stop is checked before yielding each value,
+// without blocking.
+func Interruptible[V any](seq iter.Seq[V], stop <-chan struct{}) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range seq {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // Map returns an iterator that will yield values from seq after transforming them using f.
 func Map[V any, W any](seq iter.Seq[V], f func(V) W) iter.Seq[W] {
 	return func(yield func(W) bool) {
@@ -39,6 +197,102 @@ func Map[V any, W any](seq iter.Seq[V], f func(V) W) iter.Seq[W] {
 	}
 }
 
+// ParallelMap works like Map, but runs f concurrently on the given number of worker goroutines.
+// Values are yielded in the same order as in seq, regardless of the order in which f completes.
+// ParallelMap is eager: values from seq are pulled from a separate goroutine and transformed ahead of
+// the consumer, up to twice the number of workers.
+// No call to f outlives the iteration: when the consumer stops early, pending calls to f are waited for before
+// returning, although the goroutine pulling values from seq may remain blocked in seq.
+// ParallelMap panics if workers is not strictly positive.
+func ParallelMap[V any, W any](seq iter.Seq[V], workers int, f func(V) W) iter.Seq[W] {
+	if workers <= 0 {
+		panic("itertools: ParallelMap workers must be > 0")
+	}
+
+	type job struct {
+		index int
+		value V
+	}
+	type result struct {
+		index int
+		value W
+	}
+
+	return func(yield func(W) bool) {
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+		defer func() {
+			close(done)
+			wg.Wait()
+		}()
+
+		tokens := make(chan struct{}, 2*workers)
+		jobs := make(chan job)
+		results := make(chan result)
+
+		go func() {
+			defer close(jobs)
+			i := 0
+			for v := range seq {
+				select {
+				case tokens <- struct{}{}:
+				case <-done:
+					return
+				}
+				select {
+				case jobs <- job{i, v}:
+				case <-done:
+					return
+				}
+				i++
+			}
+		}()
+
+		wg.Add(workers)
+		for range workers {
+			go func() {
+				defer wg.Done()
+				for {
+					var j job
+					var ok bool
+					select {
+					case j, ok = <-jobs:
+						if !ok {
+							return
+						}
+					case <-done:
+						return
+					}
+
+					select {
+					case results <- result{j.index, f(j.value)}:
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		pending := make(map[int]W)
+		next := 0
+		for r := range results {
+			pending[r.index] = r.value
+			for w, ok := pending[next]; ok; w, ok = pending[next] {
+				delete(pending, next)
+				next++
+				if !yield(w) {
+					return
+				}
+				<-tokens
+			}
+		}
+	}
+}
+
 // MapFromSeq2 returns an iterator that will yield values from seq after transforming them using f.
 // It is a specialization of Map for when seq is an iter.Seq2 iterator.
 func MapFromSeq2[V any, W any, X any](seq iter.Seq2[V, W], f func(V, W) X) iter.Seq[X] {
@@ -63,6 +317,68 @@ func MapToSeq2[V any, W any, X any](seq iter.Seq[V], f func(V) (W, X)) iter.Seq2
 	}
 }
 
+// KeyBy returns an iterator that will yield values from seq, each paired with the key obtained by applying key to it.
+// It is a specialization of MapToSeq2 for when values are passed through unchanged.
+func KeyBy[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq2[K, V] {
+	return MapToSeq2(seq, func(v V) (K, V) {
+		return key(v), v
+	})
+}
+
+// TryMap returns an iterator that will yield values from seq after transforming them using f,
+// each paired with the error returned by f.
+// Fallible sequences are represented as iter.Seq2[V, error] iterators, where a value must be ignored
+// if it is paired with a non-nil error.
+func TryMap[V any, W any](seq iter.Seq[V], f func(V) (W, error)) iter.Seq2[W, error] {
+	return func(yield func(W, error) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// CollectErr collects values from the fallible sequence seq into a new slice.
+// CollectErr stops at the first error, which is returned along with the values collected before it.
+func CollectErr[V any](seq iter.Seq2[V, error]) ([]V, error) {
+	var vs []V
+	for v, err := range seq {
+		if err != nil {
+			return vs, err
+		}
+		vs = append(vs, v)
+	}
+	return vs, nil
+}
+
+// Retry returns an iterator yielding values from the fallible sequence returned by makeSeq.
+// When that sequence yields an error, makeSeq is called again to restart from scratch, for a total of at most
+// attempts sequences; the error of the last one is then yielded and the iterator stops.
+// Restarting yields again the values that were already yielded, so Retry is best suited to idempotent consumers.
+// If attempts is lower than 1, makeSeq is called once.
+func Retry[V any](makeSeq func() iter.Seq2[V, error], attempts int) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for attempt := 1; ; attempt++ {
+			failed := false
+			for v, err := range makeSeq() {
+				if err != nil && attempt < attempts {
+					failed = true
+					break
+				}
+
+				if !yield(v, err) || err != nil {
+					return
+				}
+			}
+
+			if !failed {
+				return
+			}
+		}
+	}
+}
+
 // Filter returns an iterator that will yield values from seq only if they pass p.
 func Filter[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[V] {
 	return func(yield func(V) bool) {
@@ -85,6 +401,69 @@ func Reduce[V any, W any](seq iter.Seq[V], f func(W, V) W, init W) W {
 	return value
 }
 
+// Reduce1 works like Reduce, but uses the first value yielded by seq as the initial value.
+// If no values are yielded by seq, a zero-value is returned and the second return value is false.
+func Reduce1[V any](seq iter.Seq[V], f func(V, V) V) (V, bool) {
+	next, stop := iter.Pull(seq)
+	defer stop()
+
+	value, ok := next()
+	if !ok {
+		return value, false
+	}
+
+	for v, ok := next(); ok; v, ok = next() {
+		value = f(value, v)
+	}
+
+	return value, true
+}
+
+// Reduce2 reduces the pairs yielded by seq to a single value by repeatedly applying f.
+// It is a specialization of Reduce for when seq is an iter.Seq2 iterator.
+func Reduce2[K any, V any, W any](seq iter.Seq2[K, V], f func(W, K, V) W, init W) W {
+	value := init
+	for k, v := range seq {
+		value = f(value, k, v)
+	}
+	return value
+}
+
+// TryReduce works like Reduce, but stops as soon as f returns an error.
+// In that case, the value accumulated before the failing call to f is returned along with the error.
+func TryReduce[V any, W any](seq iter.Seq[V], f func(W, V) (W, error), init W) (W, error) {
+	value := init
+	for v := range seq {
+		next, err := f(value, v)
+		if err != nil {
+			return value, err
+		}
+		value = next
+	}
+	return value, nil
+}
+
+// Accumulate returns an iterator that will yield the running reductions of values from seq obtained using f.
+// The first value from seq is yielded as is and used as the initial accumulated value.
+func Accumulate[V any](seq iter.Seq[V], f func(V, V) V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var acc V
+		first := true
+		for v := range seq {
+			if first {
+				acc = v
+				first = false
+			} else {
+				acc = f(acc, v)
+			}
+
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
 // TakeWhile returns an iterator that will yield values from seq as long as they pass p.
 // The iterator stops when it encounters a value that does not pass p.
 func TakeWhile[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[V] {
@@ -97,15 +476,58 @@ func TakeWhile[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[V] {
 	}
 }
 
-// Take returns an iterator that will yield the n first values from seq.
-func Take[V any](seq iter.Seq[V], n uint) iter.Seq[V] {
-	return TakeWhile(seq, func(_ V) bool {
-		if n == 0 {
-			return false
+// TakeUntil returns an iterator that will yield values from seq up to and including the first value that passes p.
+// The iterator stops after yielding that value.
+func TakeUntil[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range seq {
+			if !yield(v) || p(v) {
+				return
+			}
 		}
-		n--
-		return true
-	})
+	}
+}
+
+// TakeWhile2 returns an iterator that will yield pairs from seq as long as they pass p.
+// It is a specialization of TakeWhile for when seq is an iter.Seq2 iterator.
+func TakeWhile2[K, V any](seq iter.Seq2[K, V], p func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if !p(k, v) || !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Take returns an iterator that will yield the n first values from seq.
+// If n is negative, no values are yielded.
+func Take[V any, N Integer](seq iter.Seq[V], n N) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		remaining := n
+		TakeWhile(seq, func(_ V) bool {
+			if remaining <= 0 {
+				return false
+			}
+			remaining--
+			return true
+		})(yield)
+	}
+}
+
+// Take2 returns an iterator that will yield the n first pairs from seq.
+// It is a specialization of Take for when seq is an iter.Seq2 iterator.
+func Take2[K, V any, N Integer](seq iter.Seq2[K, V], n N) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		remaining := n
+		TakeWhile2(seq, func(_ K, _ V) bool {
+			if remaining <= 0 {
+				return false
+			}
+			remaining--
+			return true
+		})(yield)
+	}
 }
 
 // DropWhile returns an iterator that will drop values from seq as long as they pass p.
@@ -134,15 +556,85 @@ func DropWhile[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[V] {
 	}
 }
 
+// DropWhile2 returns an iterator that will drop pairs from seq as long as they pass p.
+// It is a specialization of DropWhile for when seq is an iter.Seq2 iterator.
+func DropWhile2[K, V any](seq iter.Seq2[K, V], p func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		next, stop := iter.Pull2(seq)
+		defer stop()
+
+		for k, v, ok := next(); ok; k, v, ok = next() {
+			if p(k, v) {
+				continue
+			}
+
+			if !yield(k, v) {
+				return
+			}
+			break
+		}
+
+		for k, v, ok := next(); ok; k, v, ok = next() {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
 // Drop returns an iterator that will drop the n first values from seq.
-func Drop[V any](seq iter.Seq[V], n uint) iter.Seq[V] {
-	return DropWhile(seq, func(_ V) bool {
-		if n == 0 {
-			return false
+// If n is negative, no values are dropped.
+func Drop[V any, N Integer](seq iter.Seq[V], n N) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		remaining := n
+		DropWhile(seq, func(_ V) bool {
+			if remaining <= 0 {
+				return false
+			}
+			remaining--
+			return true
+		})(yield)
+	}
+}
+
+// Drop2 returns an iterator that will drop the n first pairs from seq.
+// It is a specialization of Drop for when seq is an iter.Seq2 iterator.
+func Drop2[K, V any, N Integer](seq iter.Seq2[K, V], n N) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		remaining := n
+		DropWhile2(seq, func(_ K, _ V) bool {
+			if remaining <= 0 {
+				return false
+			}
+			remaining--
+			return true
+		})(yield)
+	}
+}
+
+// DropLastWhile returns an iterator that will yield values from seq, except for the trailing values that pass p.
+// Consecutive values that pass p are buffered, and only yielded once a value that does not pass p follows them.
+func DropLastWhile[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var pending []V
+		for v := range seq {
+			if p(v) {
+				pending = append(pending, v)
+				continue
+			}
+
+			for _, w := range pending {
+				if !yield(w) {
+					return
+				}
+			}
+			pending = pending[:0]
+
+			if !yield(v) {
+				return
+			}
 		}
-		n--
-		return true
-	})
+	}
 }
 
 // Chain returns an iterator that will first yield all the values from seq1, then all the values from seq2.
@@ -161,6 +653,20 @@ func Chain[V any](seq1, seq2 iter.Seq[V]) iter.Seq[V] {
 	}
 }
 
+// Chain2 returns an iterator that will yield all the pairs from each of seqs, one after the other.
+// It is a specialization of Chain for iter.Seq2 iterators, accepting any number of them.
+func Chain2[K, V any](seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, seq := range seqs {
+			for k, v := range seq {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // WithFunc returns an iterator yielding values obtained by indefinitely calling f.
 func WithFunc[V any](f func() V) iter.Seq[V] {
 	return func(yield func(V) bool) {
@@ -172,13 +678,29 @@ func WithFunc[V any](f func() V) iter.Seq[V] {
 	}
 }
 
+// WithFuncErr returns a fallible iterator yielding values and errors obtained by repeatedly calling f.
+// The iterator stops when f returns false as its last return value, in which case the other ones are ignored.
+func WithFuncErr[V any](f func() (V, error, bool)) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for {
+			v, err, ok := f()
+			if !ok || !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
 // Repeat returns an iterator that will indefinitely yield v.
+// To repeat a whole sequence rather than a single value, use Cycle.
 func Repeat[V any](v V) iter.Seq[V] {
 	return WithFunc(func() V { return v })
 }
 
 // RepeatN works like Repeat, but returns an iterator that stops after yielding n values.
-func RepeatN[V any](v V, n uint) iter.Seq[V] {
+// If n is negative, no values are yielded.
+// To repeat a whole sequence a bounded number of times, use CycleN.
+func RepeatN[V any, N Integer](v V, n N) iter.Seq[V] {
 	return Take(Repeat(v), n)
 }
 
@@ -206,15 +728,167 @@ func Cycle[V any](seq iter.Seq[V]) iter.Seq[V] {
 	}
 }
 
-// Flatten returns an iterator that yields each value from a nested iterator.
-func Flatten[V any](seq iter.Seq[iter.Seq[V]]) iter.Seq[V] {
+// CycleN works like Cycle, but returns an iterator that stops after cycling through seq n times.
+// If n is negative, no values are yielded.
+func CycleN[V any, N Integer](seq iter.Seq[V], n N) iter.Seq[V] {
 	return func(yield func(V) bool) {
-		for s := range seq {
-			for v := range s {
+		if n <= 0 {
+			return
+		}
+
+		var vs []V
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			vs = append(vs, v)
+		}
+
+		for i := N(1); i < n && len(vs) > 0; i++ {
+			for _, v := range vs {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Cached returns an iterator yielding the values from seq, that can be iterated over multiple times
+// even if seq itself can only be iterated over once.
+// Values are pulled from seq lazily, the first time they are requested, and recorded into a slice
+// that is replayed by subsequent iterations.
+// Resources held by seq are only released once seq is exhausted.
+// The returned iterator must not be iterated over concurrently.
+func Cached[V any](seq iter.Seq[V]) iter.Seq[V] {
+	var vs []V
+	var next func() (V, bool)
+	var stop func()
+	exhausted := false
+
+	return func(yield func(V) bool) {
+		for i := 0; ; i++ {
+			if i == len(vs) {
+				if exhausted {
+					return
+				}
+				if next == nil {
+					next, stop = iter.Pull(seq)
+				}
+				v, ok := next()
+				if !ok {
+					exhausted = true
+					stop()
+					return
+				}
+				vs = append(vs, v)
+			}
+
+			if !yield(vs[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Flatten returns an iterator that yields each value from a nested iterator.
+func Flatten[V any](seq iter.Seq[iter.Seq[V]]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for s := range seq {
+			for v := range s {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlattenInterleave returns an iterator that yields each value from a nested iterator, taking one value from each
+// inner iterator in turn rather than draining them one after the other. Exhausted inner iterators are skipped.
+// The outer iterator is fully consumed before the first value is yielded.
+func FlattenInterleave[V any](seq iter.Seq[iter.Seq[V]]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var nexts []func() (V, bool)
+		var stops []func()
+		defer func() {
+			for _, stop := range stops {
+				stop()
+			}
+		}()
+
+		for s := range seq {
+			next, stop := iter.Pull(s)
+			nexts = append(nexts, next)
+			stops = append(stops, stop)
+		}
+
+		for len(nexts) > 0 {
+			active := nexts[:0]
+			for _, next := range nexts {
+				v, ok := next()
+				if !ok {
+					continue
+				}
+				active = append(active, next)
+
 				if !yield(v) {
 					return
 				}
 			}
+			nexts = active
+		}
+	}
+}
+
+// FlattenValues returns an iterator that yields each value from the nested iterators of seq, paired with the key
+// that the nested iterator was paired with.
+func FlattenValues[K, V any](seq iter.Seq2[K, iter.Seq[V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, s := range seq {
+			for v := range s {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlatMap2 returns an iterator that transforms each pair from seq into an iterator of pairs using f, and yields each
+// pair from the resulting iterators.
+func FlatMap2[K, V, K2, V2 any](seq iter.Seq2[K, V], f func(K, V) iter.Seq2[K2, V2]) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range seq {
+			for k2, v2 := range f(k, v) {
+				if !yield(k2, v2) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Flatten2 returns an iterator that yields each value from a doubly nested iterator.
+func Flatten2[V any](seq iter.Seq[iter.Seq[iter.Seq[V]]]) iter.Seq[V] {
+	return Flatten(Flatten(seq))
+}
+
+// Flatten3 returns an iterator that yields each value from a triply nested iterator.
+func Flatten3[V any](seq iter.Seq[iter.Seq[iter.Seq[iter.Seq[V]]]]) iter.Seq[V] {
+	return Flatten(Flatten2(seq))
+}
+
+// FlattenErr returns an iterator that yields each value from a nested fallible iterator.
+// The iterator stops after yielding the first error encountered in any of the nested iterators.
+func FlattenErr[V any](seq iter.Seq[iter.Seq2[V, error]]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for s := range seq {
+			for v, err := range s {
+				if !yield(v, err) || err != nil {
+					return
+				}
+			}
 		}
 	}
 }
@@ -303,6 +977,40 @@ func Max[V cmp.Ordered](seq iter.Seq[V]) (V, bool) {
 	return MaxFunc(seq, cmp.Compare)
 }
 
+// MinBy returns the value yielded by seq for which key returns the minimum value.
+// If no values are yielded by seq, a zero-value is returned and the second return value is false.
+// If there is more than one minimal element according to key, MinBy returns the first one.
+func MinBy[V any, K cmp.Ordered](seq iter.Seq[V], key func(V) K) (V, bool) {
+	return extremumBy(seq, key, cmp.Less[K])
+}
+
+// MaxBy returns the value yielded by seq for which key returns the maximum value.
+// If no values are yielded by seq, a zero-value is returned and the second return value is false.
+// If there is more than one maximal element according to key, MaxBy returns the first one.
+func MaxBy[V any, K cmp.Ordered](seq iter.Seq[V], key func(V) K) (V, bool) {
+	return extremumBy(seq, key, func(a, b K) bool { return cmp.Less(b, a) })
+}
+
+// extremumBy returns the first value yielded by seq whose key is not beaten by any other according to better.
+func extremumBy[V any, K any](seq iter.Seq[V], key func(V) K, better func(K, K) bool) (V, bool) {
+	next, stop := iter.Pull(seq)
+	defer stop()
+
+	bestV, ok := next()
+	if !ok {
+		return bestV, false
+	}
+	bestK := key(bestV)
+
+	for v, ok := next(); ok; v, ok = next() {
+		if k := key(v); better(k, bestK) {
+			bestV, bestK = v, k
+		}
+	}
+
+	return bestV, true
+}
+
 // InterleaveShortest returns an iterator that will yield values from seq1 and seq2 alternatively, starting with seq1.
 // The iterator stops after the iterator whose turn it is to produce a value is exhausted.
 func InterleaveShortest[V any](seq1, seq2 iter.Seq[V]) iter.Seq[V] {
@@ -378,6 +1086,42 @@ func InterleaveLongest[V any](seq1, seq2 iter.Seq[V]) iter.Seq[V] {
 	}
 }
 
+// InterleaveBy returns an iterator that will yield values from seqs, in the order dictated by order:
+// each index yielded by order designates the sequence from seqs to take the next value from.
+// The iterator stops after either order or the requested sequence is exhausted.
+// InterleaveBy panics if order yields an index that is out of the bounds of seqs.
+func InterleaveBy[V any](order iter.Seq[int], seqs ...iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		nexts := make([]func() (V, bool), len(seqs))
+		stops := make([]func(), len(seqs))
+		defer func() {
+			for _, stop := range stops {
+				if stop != nil {
+					stop()
+				}
+			}
+		}()
+
+		for i := range order {
+			if i < 0 || i >= len(seqs) {
+				panic("itertools: InterleaveBy index out of range")
+			}
+			if nexts[i] == nil {
+				nexts[i], stops[i] = iter.Pull(seqs[i])
+			}
+
+			v, ok := nexts[i]()
+			if !ok {
+				return
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // ZipShortest returns an iterator that will yield values from seq1 and seq2 simultaneously.
 // The iterator stops after either seq1 or seq2 stops.
 func ZipShortest[V, W any](seq1 iter.Seq[V], seq2 iter.Seq[W]) iter.Seq2[V, W] {
@@ -405,10 +1149,97 @@ func ZipShortest[V, W any](seq1 iter.Seq[V], seq2 iter.Seq[W]) iter.Seq2[V, W] {
 	}
 }
 
+// Pair holds two values of possibly different types.
+// Unlike the values yielded by an iter.Seq2 iterator, pairs can be stored, e.g. in slices.
+type Pair[V, W any] struct {
+	First  V
+	Second W
+}
+
+// Swap returns a new pair with the values of p in reversed order.
+func (p Pair[V, W]) Swap() Pair[W, V] {
+	return Pair[W, V]{First: p.Second, Second: p.First}
+}
+
+// Pairs returns an iterator that will yield values from seq1 and seq2 simultaneously, as pairs.
+// The iterator stops after either seq1 or seq2 stops.
+func Pairs[V, W any](seq1 iter.Seq[V], seq2 iter.Seq[W]) iter.Seq[Pair[V, W]] {
+	return MapFromSeq2(ZipShortest(seq1, seq2), func(v V, w W) Pair[V, W] {
+		return Pair[V, W]{First: v, Second: w}
+	})
+}
+
+// ToPairs returns an iterator that will yield the pairs from seq as Pair values.
+func ToPairs[K, V any](seq iter.Seq2[K, V]) iter.Seq[Pair[K, V]] {
+	return MapFromSeq2(seq, func(k K, v V) Pair[K, V] {
+		return Pair[K, V]{First: k, Second: v}
+	})
+}
+
+// FromPairs returns an iterator that will yield the values of the Pair values from seq as pairs.
+// It is the inverse of ToPairs.
+func FromPairs[K, V any](seq iter.Seq[Pair[K, V]]) iter.Seq2[K, V] {
+	return MapToSeq2(seq, func(p Pair[K, V]) (K, V) {
+		return p.First, p.Second
+	})
+}
+
+// Triple holds three values of possibly different types.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Zip3 returns an iterator that will yield values from a, b and c simultaneously, as triples.
+// The iterator stops after either a, b or c stops.
+func Zip3[A, B, C any](a iter.Seq[A], b iter.Seq[B], c iter.Seq[C]) iter.Seq[Triple[A, B, C]] {
+	return func(yield func(Triple[A, B, C]) bool) {
+		anext, astop := iter.Pull(a)
+		bnext, bstop := iter.Pull(b)
+		cnext, cstop := iter.Pull(c)
+		defer astop()
+		defer bstop()
+		defer cstop()
+
+		for {
+			av, ok := anext()
+			if !ok {
+				return
+			}
+
+			bv, ok := bnext()
+			if !ok {
+				return
+			}
+
+			cv, ok := cnext()
+			if !ok {
+				return
+			}
+
+			if !yield(Triple[A, B, C]{First: av, Second: bv, Third: cv}) {
+				return
+			}
+		}
+	}
+}
+
 // ChunkBy returns an iterator that groups values from seq according to key and yields those groups.
 // Consecutive elements that map to the same key are assigned to the same group.
 func ChunkBy[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq[iter.Seq[V]] {
 	return func(yield func(iter.Seq[V]) bool) {
+		for _, group := range GroupAdjacent(seq, key) {
+			if !yield(group) {
+				return
+			}
+		}
+	}
+}
+
+// GroupAdjacent works like ChunkBy, but returns an iterator that yields each group along with its key.
+func GroupAdjacent[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq2[K, iter.Seq[V]] {
+	return func(yield func(K, iter.Seq[V]) bool) {
 		var vs []V
 		next, stop := iter.Pull(seq)
 		defer stop()
@@ -424,13 +1255,13 @@ func ChunkBy[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq[iter.
 		for {
 			v, ok = next()
 			if !ok {
-				yield(FromSlice(vs))
+				yield(lastK, FromSlice(vs))
 				return
 			}
 
 			k = key(v)
 			if k != lastK {
-				if !yield(FromSlice(vs)) {
+				if !yield(lastK, FromSlice(vs)) {
 					return
 				}
 				lastK = k
@@ -442,14 +1273,163 @@ func ChunkBy[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq[iter.
 	}
 }
 
+// ChunkByChanges returns an iterator that groups runs of equal consecutive values from seq and yields those groups.
+// It is equivalent to ChunkBy with a key function returning values themselves.
+func ChunkByChanges[V comparable](seq iter.Seq[V]) iter.Seq[iter.Seq[V]] {
+	return ChunkBy(seq, Identity[V])
+}
+
+// ChunkByIndexed works like ChunkBy, but returns an iterator that yields each group along with its zero-based index.
+func ChunkByIndexed[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq2[int, iter.Seq[V]] {
+	return func(yield func(int, iter.Seq[V]) bool) {
+		i := 0
+		for _, group := range GroupAdjacent(seq, key) {
+			if !yield(i, group) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// ChunkCounts works like GroupAdjacent, but returns an iterator that yields the number of values in each group
+// rather than the group itself, so that values do not need to be buffered.
+func ChunkCounts[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq2[K, int] {
+	return func(yield func(K, int) bool) {
+		var lastK K
+		count := 0
+		for v := range seq {
+			k := key(v)
+			if count > 0 && k != lastK {
+				if !yield(lastK, count) {
+					return
+				}
+				count = 0
+			}
+			lastK = k
+			count++
+		}
+
+		if count > 0 {
+			yield(lastK, count)
+		}
+	}
+}
+
+// ChunkWhile returns an iterator that groups consecutive values from seq and yields those groups.
+// A new group is started whenever sameGroup, called with the previous and the current value, returns false.
+func ChunkWhile[V any](seq iter.Seq[V], sameGroup func(prev, cur V) bool) iter.Seq[iter.Seq[V]] {
+	return func(yield func(iter.Seq[V]) bool) {
+		var vs []V
+		next, stop := iter.Pull(seq)
+		defer stop()
+
+		prev, ok := next()
+		if !ok {
+			return
+		}
+		vs = append(vs, prev)
+
+		for v, ok := next(); ok; v, ok = next() {
+			if !sameGroup(prev, v) {
+				if !yield(FromSlice(vs)) {
+					return
+				}
+				vs = nil
+			}
+			vs = append(vs, v)
+			prev = v
+		}
+
+		yield(FromSlice(vs))
+	}
+}
+
 // Chunks returns an iterator that chunks values from seq into groups of size s.
-func Chunks[V any](seq iter.Seq[V], s uint) iter.Seq[iter.Seq[V]] {
-	i := uint(0)
-	return ChunkBy(seq, func(_ V) uint {
-		k := i / s
-		i++
-		return k
-	})
+// Chunks panics if s is not strictly positive.
+func Chunks[V any, N Integer](seq iter.Seq[V], s N) iter.Seq[iter.Seq[V]] {
+	if s <= 0 {
+		panic("itertools: Chunks size must be > 0")
+	}
+
+	return func(yield func(iter.Seq[V]) bool) {
+		k := 0
+		count := N(0)
+		ChunkBy(seq, func(_ V) int {
+			if count == s {
+				k++
+				count = 0
+			}
+			count++
+			return k
+		})(yield)
+	}
+}
+
+// ChunksPadded returns an iterator that chunks values from seq into slices of size values, padding the last chunk
+// with pad so that every chunk holds exactly size values.
+// Each chunk is a fresh slice that can be safely retained by the consumer.
+// No chunks are yielded if seq is empty.
+// ChunksPadded panics if size is not strictly positive.
+func ChunksPadded[V any, N Integer](seq iter.Seq[V], size N, pad V) iter.Seq[[]V] {
+	if size <= 0 {
+		panic("itertools: ChunksPadded size must be > 0")
+	}
+
+	return func(yield func([]V) bool) {
+		n := int(size)
+		chunk := make([]V, 0, n)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]V, 0, n)
+			}
+		}
+
+		if len(chunk) > 0 {
+			for len(chunk) < n {
+				chunk = append(chunk, pad)
+			}
+			yield(chunk)
+		}
+	}
+}
+
+// WindowReduce returns an iterator that applies f to each overlapping window of size consecutive values from seq,
+// and yields the results.
+// Windows are backed by a ring buffer that is reused between calls to f, which must not retain them.
+// No values are yielded if seq yields fewer than size values.
+// WindowReduce panics if size is not strictly positive.
+func WindowReduce[V any, W any, N Integer](seq iter.Seq[V], size N, f func([]V) W) iter.Seq[W] {
+	if size <= 0 {
+		panic("itertools: WindowReduce size must be > 0")
+	}
+
+	return func(yield func(W) bool) {
+		n := int(size)
+		buf := make([]V, 2*n)
+		i, count := 0, 0
+
+		for v := range seq {
+			buf[i] = v
+			buf[i+n] = v
+			i = (i + 1) % n
+
+			if count < n {
+				count++
+				if count < n {
+					continue
+				}
+			}
+
+			if !yield(f(buf[i : i+n])) {
+				return
+			}
+		}
+	}
 }
 
 // ReverseSlice returns an iterator that will yield values from vs in reversed order/
@@ -486,3 +1466,1407 @@ func IsSortedFunc[V any](seq iter.Seq[V], cmp func(V, V) int) bool {
 func IsSorted[V cmp.Ordered](seq iter.Seq[V]) bool {
 	return IsSortedFunc(seq, cmp.Compare)
 }
+
+// IsSortedByKey reports whether the keys of pairs yielded by seq are sorted in ascending order.
+func IsSortedByKey[K cmp.Ordered, V any](seq iter.Seq2[K, V]) bool {
+	next, stop := iter.Pull2(seq)
+	defer stop()
+	prev, _, ok := next()
+	if !ok {
+		return true
+	}
+
+	for k, _, ok := next(); ok; k, _, ok = next() {
+		if cmp.Less(k, prev) {
+			return false
+		}
+		prev = k
+	}
+
+	return true
+}
+
+// UniqueKeys returns an iterator that will yield pairs from seq, only keeping the first pair for each key.
+// Keys that were already seen are recorded into a set, which grows with the number of distinct keys.
+func UniqueKeys[K comparable, V any](seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		seen := make(map[K]struct{})
+		for k, v := range seq {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Stats returns the mean and population variance of the values yielded by seq, along with their count.
+// Values are processed in a single pass using Welford's online algorithm, which is numerically stable.
+// If no values are yielded by seq, count is zero and so are mean and variance.
+func Stats[V Numeric](seq iter.Seq[V]) (mean, variance float64, count int) {
+	var m2 float64
+	for v := range seq {
+		x := float64(v)
+		count++
+		delta := x - mean
+		mean += delta / float64(count)
+		m2 += delta * (x - mean)
+	}
+
+	if count == 0 {
+		return 0, 0, 0
+	}
+	return mean, m2 / float64(count), count
+}
+
+// Counted returns an iterator that will yield values from seq unchanged, incrementing *counter for each of them.
+// This allows measuring how many values were actually pulled by the consumer.
+func Counted[V any](seq iter.Seq[V], counter *int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range seq {
+			*counter++
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Sample returns up to k values chosen uniformly at random from seq, using rng as the source of randomness.
+// Values are selected in a single pass using reservoir sampling, so that only k values are held in memory.
+// If seq yields fewer than k values, all of them are returned.
+func Sample[V any](seq iter.Seq[V], k int, rng *rand.Rand) []V {
+	if k <= 0 {
+		return nil
+	}
+
+	var vs []V
+	i := 0
+	for v := range seq {
+		if i < k {
+			vs = append(vs, v)
+		} else if j := rng.IntN(i + 1); j < k {
+			vs[j] = v
+		}
+		i++
+	}
+	return vs
+}
+
+// Shuffle returns an iterator that will yield values from seq in a random order, using rng as the source of randomness.
+// Shuffle is not lazy: all the values from seq are collected into a slice and shuffled using the Fisher-Yates
+// algorithm before the first one is yielded, which makes it unsuitable for infinite iterators.
+func Shuffle[V any](seq iter.Seq[V], rng *rand.Rand) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var vs []V
+		for v := range seq {
+			vs = append(vs, v)
+		}
+
+		for i := len(vs) - 1; i > 0; i-- {
+			j := rng.IntN(i + 1)
+			vs[i], vs[j] = vs[j], vs[i]
+		}
+
+		for _, v := range vs {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Histogram counts the values yielded by seq into bins equal-width buckets spanning the [lo, hi] range.
+// Values that fall outside of that range are clamped, i.e. counted in the first or last bucket, and NaN values are
+// not counted.
+// If no values are yielded by seq, a slice of bins zeros is returned.
+// Histogram panics if bins is not strictly positive or if lo is not lower than hi.
+func Histogram[V Numeric](seq iter.Seq[V], lo, hi V, bins int) []int {
+	if bins <= 0 {
+		panic("itertools: Histogram bins must be > 0")
+	}
+	if lo >= hi {
+		panic("itertools: Histogram lo must be < hi")
+	}
+
+	counts := make([]int, bins)
+	width := float64(hi) - float64(lo)
+	for v := range seq {
+		f := (float64(v) - float64(lo)) / width * float64(bins)
+		switch {
+		case math.IsNaN(f):
+			continue
+		case f >= float64(bins):
+			counts[bins-1]++
+		case f < 0:
+			counts[0]++
+		default:
+			counts[int(f)]++
+		}
+	}
+	return counts
+}
+
+// MostCommon returns an iterator that will yield the n most frequent values from seq, each paired with its count,
+// by descending count. Values with the same count are yielded in order of first appearance.
+// MostCommon is not lazy: all the values from seq are counted before the first one is yielded.
+func MostCommon[V comparable](seq iter.Seq[V], n int) iter.Seq2[V, int] {
+	return func(yield func(V, int) bool) {
+		vs, counts := tally(seq)
+		slices.SortStableFunc(vs, func(a, b V) int {
+			return counts[b] - counts[a]
+		})
+
+		for _, v := range vs[:max(0, min(n, len(vs)))] {
+			if !yield(v, counts[v]) {
+				return
+			}
+		}
+	}
+}
+
+// tally counts the occurrences of each value from seq.
+// It also returns the distinct values, in order of first appearance.
+func tally[V comparable](seq iter.Seq[V]) ([]V, map[V]int) {
+	var vs []V
+	counts := make(map[V]int)
+	for v := range seq {
+		if counts[v] == 0 {
+			vs = append(vs, v)
+		}
+		counts[v]++
+	}
+	return vs, counts
+}
+
+// Iterator wraps an iterator to allow chaining transformations using methods, rather than nested function calls.
+// Since Go methods cannot have type parameters, only transformations that preserve the type of values are
+// available as methods: use MapPipe to map values to a different type.
+type Iterator[V any] struct {
+	seq iter.Seq[V]
+}
+
+// From returns an Iterator wrapping seq.
+func From[V any](seq iter.Seq[V]) *Iterator[V] {
+	return &Iterator[V]{seq: seq}
+}
+
+// Seq returns the iterator wrapped by it.
+func (it *Iterator[V]) Seq() iter.Seq[V] {
+	return it.seq
+}
+
+// Map works like the Map function, restricted to f not changing the type of values.
+func (it *Iterator[V]) Map(f func(V) V) *Iterator[V] {
+	return From(Map(it.seq, f))
+}
+
+// Filter works like the Filter function.
+func (it *Iterator[V]) Filter(p func(V) bool) *Iterator[V] {
+	return From(Filter(it.seq, p))
+}
+
+// TakeWhile works like the TakeWhile function.
+func (it *Iterator[V]) TakeWhile(p func(V) bool) *Iterator[V] {
+	return From(TakeWhile(it.seq, p))
+}
+
+// Take works like the Take function.
+func (it *Iterator[V]) Take(n int) *Iterator[V] {
+	return From(Take(it.seq, n))
+}
+
+// DropWhile works like the DropWhile function.
+func (it *Iterator[V]) DropWhile(p func(V) bool) *Iterator[V] {
+	return From(DropWhile(it.seq, p))
+}
+
+// Drop works like the Drop function.
+func (it *Iterator[V]) Drop(n int) *Iterator[V] {
+	return From(Drop(it.seq, n))
+}
+
+// Collect collects the values yielded by it into a new slice.
+func (it *Iterator[V]) Collect() []V {
+	return slices.Collect(it.seq)
+}
+
+// ForEach calls f on each value yielded by it.
+func (it *Iterator[V]) ForEach(f func(V)) {
+	for v := range it.seq {
+		f(v)
+	}
+}
+
+// MapPipe works like the Map function, but operates on an Iterator.
+// It allows changing the type of values in the middle of a chain of Iterator methods.
+func MapPipe[V any, W any](it *Iterator[V], f func(V) W) *Iterator[W] {
+	return From(Map(it.seq, f))
+}
+
+// Pipe returns an iterator obtained by applying each of transforms to seq, in order.
+// This allows composing transformations that are only known at runtime.
+func Pipe[V any](seq iter.Seq[V], transforms ...func(iter.Seq[V]) iter.Seq[V]) iter.Seq[V] {
+	for _, t := range transforms {
+		seq = t(seq)
+	}
+	return seq
+}
+
+// Join concatenates the strings yielded by seq, placing sep between each of them.
+func Join(seq iter.Seq[string], sep string) string {
+	var b strings.Builder
+	first := true
+	for s := range seq {
+		if !first {
+			b.WriteString(sep)
+		}
+		b.WriteString(s)
+		first = false
+	}
+	return b.String()
+}
+
+// FirstN collects at most the n first values yielded by seq into a new slice.
+// seq is stopped as soon as n values have been collected.
+// If n is negative, no values are collected.
+func FirstN[V any, N Integer](seq iter.Seq[V], n N) []V {
+	var vs []V
+	if n <= 0 {
+		return vs
+	}
+
+	for v := range seq {
+		vs = append(vs, v)
+		if N(len(vs)) == n {
+			break
+		}
+	}
+	return vs
+}
+
+// LastN collects at most the n last values yielded by seq into a new slice.
+// Values are accumulated into a ring buffer of size n, so seq must be finite.
+// If n is negative, no values are collected.
+func LastN[V any, N Integer](seq iter.Seq[V], n N) []V {
+	if n <= 0 {
+		return nil
+	}
+
+	var buf []V
+	i := 0
+	for v := range seq {
+		if N(len(buf)) < n {
+			buf = append(buf, v)
+			continue
+		}
+		buf[i] = v
+		i = (i + 1) % len(buf)
+	}
+	return append(buf[i:], buf[:i]...)
+}
+
+// SortBy returns an iterator that will yield values from seq in ascending order of the keys returned by key.
+// The key of each value is only computed once, and values with equal keys keep their relative order.
+// SortBy is not lazy: all the values from seq are collected and sorted before the first one is yielded.
+func SortBy[V any, K cmp.Ordered](seq iter.Seq[V], key func(V) K) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var kvs []Pair[K, V]
+		for v := range seq {
+			kvs = append(kvs, Pair[K, V]{First: key(v), Second: v})
+		}
+
+		slices.SortStableFunc(kvs, func(a, b Pair[K, V]) int {
+			return cmp.Compare(a.First, b.First)
+		})
+
+		for _, kv := range kvs {
+			if !yield(kv.Second) {
+				return
+			}
+		}
+	}
+}
+
+// Clamp returns an iterator that will yield values from seq after clamping them to the [lo, hi] range.
+// Clamp panics if lo is greater than hi.
+func Clamp[V cmp.Ordered](seq iter.Seq[V], lo, hi V) iter.Seq[V] {
+	if lo > hi {
+		panic("itertools: Clamp lo must be <= hi")
+	}
+
+	return Map(seq, func(v V) V {
+		return min(max(v, lo), hi)
+	})
+}
+
+// ScanRight returns an iterator that will yield the running reductions of values from seq, obtained by
+// repeatedly applying f starting from the last value: the first yielded value is f(last, init), and the last
+// yielded value is the reduction of all the values from seq.
+// ScanRight is not lazy: all the values from seq are collected into a slice before the first result is yielded.
+func ScanRight[V any, W any](seq iter.Seq[V], f func(V, W) W, init W) iter.Seq[W] {
+	return func(yield func(W) bool) {
+		var vs []V
+		for v := range seq {
+			vs = append(vs, v)
+		}
+
+		acc := init
+		for v := range ReverseSlice(vs) {
+			acc = f(v, acc)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// CountDistinct returns the number of distinct values yielded by seq.
+// Values that were already seen are recorded into a set, which grows with the number of distinct values.
+func CountDistinct[V comparable](seq iter.Seq[V]) int {
+	seen := make(map[V]struct{})
+	for v := range seq {
+		seen[v] = struct{}{}
+	}
+	return len(seen)
+}
+
+// Coalesce returns an iterator that merges runs of consecutive values from seq using combine, and yields the
+// merged value of each run.
+// A new run is started whenever boundary, called with the previous and the current value from seq, returns true.
+func Coalesce[V any](seq iter.Seq[V], combine func(V, V) V, boundary func(prev, cur V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		next, stop := iter.Pull(seq)
+		defer stop()
+
+		prev, ok := next()
+		if !ok {
+			return
+		}
+		acc := prev
+
+		for v, ok := next(); ok; v, ok = next() {
+			if boundary(prev, v) {
+				if !yield(acc) {
+					return
+				}
+				acc = v
+			} else {
+				acc = combine(acc, v)
+			}
+			prev = v
+		}
+
+		yield(acc)
+	}
+}
+
+// EMA returns an iterator that will yield the exponential moving average of values from seq, seeded with the
+// first value: each subsequent result is alpha*cur + (1-alpha)*prev.
+// EMA panics if alpha is not in the (0, 1] range.
+func EMA(seq iter.Seq[float64], alpha float64) iter.Seq[float64] {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("itertools: EMA alpha must be in (0, 1]")
+	}
+
+	return Accumulate(seq, func(prev, cur float64) float64 {
+		return alpha*cur + (1-alpha)*prev
+	})
+}
+
+// JoinByKey returns an iterator performing an inner join of left and right on their keys: for each pair from left
+// whose key also appears in right, it yields the key along with both values, once per matching pair from right.
+// right is entirely buffered into a map before the first pair is yielded, while left is streamed, so that pairs are
+// yielded in the order of left.
+func JoinByKey[K comparable, V, W any](left iter.Seq2[K, V], right iter.Seq2[K, W]) iter.Seq2[K, Pair[V, W]] {
+	return func(yield func(K, Pair[V, W]) bool) {
+		ws := make(map[K][]W)
+		for k, w := range right {
+			ws[k] = append(ws[k], w)
+		}
+
+		for k, v := range left {
+			for _, w := range ws[k] {
+				if !yield(k, Pair[V, W]{First: v, Second: w}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ExcludeKeys returns an iterator that will yield pairs from seq, only if their key is not yielded by exclude.
+// exclude is entirely buffered into a set before the first pair is yielded.
+func ExcludeKeys[K comparable, V any](seq iter.Seq2[K, V], exclude iter.Seq[K]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		excluded := make(map[K]struct{})
+		for k := range exclude {
+			excluded[k] = struct{}{}
+		}
+
+		for k, v := range seq {
+			if _, ok := excluded[k]; ok {
+				continue
+			}
+
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Throttle returns an iterator that will yield values from seq, sleeping as needed so that at least minInterval
+// elapses between consecutive values. Use ThrottleContext to make those sleeps cancellable.
+func Throttle[V any](seq iter.Seq[V], minInterval time.Duration) iter.Seq[V] {
+	return ThrottleContext(context.Background(), seq, minInterval)
+}
+
+// ThrottleContext works like Throttle, but stops as soon as ctx is done, including while sleeping.
+func ThrottleContext[V any](ctx context.Context, seq iter.Seq[V], minInterval time.Duration) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var last time.Time
+		for v := range seq {
+			if !last.IsZero() {
+				if wait := minInterval - time.Since(last); wait > 0 {
+					timer := time.NewTimer(wait)
+					select {
+					case <-timer.C:
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					}
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			last = time.Now()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// WithTimeout returns an iterator that will yield values from seq, and stops if seq does not produce a value within
+// d after the consumer requested it.
+// seq is run in a separate goroutine: a value that it produces after the timeout fired is dropped, and the goroutine
+// exits as soon as seq produces its next value.
+func WithTimeout[V any](seq iter.Seq[V], d time.Duration) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch := ToChannelContext(ctx, seq, 0)
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok || !yield(v) {
+					return
+				}
+				timer.Reset(d)
+			case <-timer.C:
+				return
+			}
+		}
+	}
+}
+
+// GroupByReduce groups the values yielded by seq according to key, and reduces each group to a single value by
+// repeatedly applying f, in a single pass. The initial value of each group is obtained by calling init.
+func GroupByReduce[V any, K comparable, W any](seq iter.Seq[V], key func(V) K, f func(W, V) W, init func() W) map[K]W {
+	groups := make(map[K]W)
+	for v := range seq {
+		k := key(v)
+		acc, ok := groups[k]
+		if !ok {
+			acc = init()
+		}
+		groups[k] = f(acc, v)
+	}
+	return groups
+}
+
+// Ngrams returns an iterator that will yield each overlapping window of n consecutive values from seq.
+// Each n-gram is a fresh slice that can be safely retained by the consumer.
+// No values are yielded if seq yields fewer than n values.
+// Ngrams panics if n is not strictly positive.
+func Ngrams[V any](seq iter.Seq[V], n int) iter.Seq[[]V] {
+	if n <= 0 {
+		panic("itertools: Ngrams n must be > 0")
+	}
+
+	return WindowReduce(seq, n, slices.Clone[[]V])
+}
+
+// Product returns an iterator that will yield the Cartesian product of seqs, as slices holding one value from each
+// sequence, in the order of seqs. Tuples are yielded in lexicographic order: the last sequence varies fastest.
+// Each tuple is a fresh slice that can be safely retained by the consumer.
+// All the sequences but the first one are buffered before the first tuple is yielded, so they must be finite.
+// A single empty tuple is yielded if seqs is empty, and no tuples are yielded if any of seqs is empty.
+func Product[V any](seqs ...iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		if len(seqs) == 0 {
+			yield([]V{})
+			return
+		}
+
+		rest := make([][]V, len(seqs)-1)
+		for i, s := range seqs[1:] {
+			rest[i] = slices.Collect(s)
+			if len(rest[i]) == 0 {
+				return
+			}
+		}
+
+		indices := make([]int, len(rest))
+		for v := range seqs[0] {
+			clear(indices)
+			for {
+				tuple := make([]V, 0, len(seqs))
+				tuple = append(tuple, v)
+				for i, j := range indices {
+					tuple = append(tuple, rest[i][j])
+				}
+				if !yield(tuple) {
+					return
+				}
+
+				i := len(indices) - 1
+				for ; i >= 0; i-- {
+					indices[i]++
+					if indices[i] < len(rest[i]) {
+						break
+					}
+					indices[i] = 0
+				}
+				if i < 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+// PowerSet returns an iterator that will yield every subset of vs, preserving the order of vs within each subset.
+// Subsets follow binary counting order, vs[0] being the least significant bit: for [a b c], the iterator yields
+// [], [a], [b], [a b], [c], [a c], [b c] and [a b c].
+// Each subset is a fresh slice that can be safely retained by the consumer.
+func PowerSet[V any](vs []V) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		included := make([]bool, len(vs))
+		for {
+			subset := []V{}
+			for i, v := range vs {
+				if included[i] {
+					subset = append(subset, v)
+				}
+			}
+			if !yield(subset) {
+				return
+			}
+
+			i := 0
+			for ; i < len(included) && included[i]; i++ {
+				included[i] = false
+			}
+			if i == len(included) {
+				return
+			}
+			included[i] = true
+		}
+	}
+}
+
+// Positions returns an iterator that will yield the zero-based indices of the values from seq that pass p.
+func Positions[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		i := 0
+		for v := range seq {
+			if p(v) {
+				if !yield(i) {
+					return
+				}
+			}
+			i++
+		}
+	}
+}
+
+// WindowMax returns an iterator that will yield the maximum of each overlapping window of size consecutive values
+// from seq. It relies on a monotonic deque, so that each value is processed in amortized constant time.
+// No values are yielded if seq yields fewer than size values.
+// WindowMax panics if size is not strictly positive.
+func WindowMax[V cmp.Ordered, N Integer](seq iter.Seq[V], size N) iter.Seq[V] {
+	if size <= 0 {
+		panic("itertools: WindowMax size must be > 0")
+	}
+
+	return windowExtremum(seq, int(size), func(a, b V) bool { return a <= b })
+}
+
+// WindowMin works like WindowMax, but yields the minimum of each window instead.
+func WindowMin[V cmp.Ordered, N Integer](seq iter.Seq[V], size N) iter.Seq[V] {
+	if size <= 0 {
+		panic("itertools: WindowMin size must be > 0")
+	}
+
+	return windowExtremum(seq, int(size), func(a, b V) bool { return a >= b })
+}
+
+// windowExtremum yields the extremum of each window of size values from seq.
+// dominated reports whether a value can be dropped from the deque because of a more recent value.
+func windowExtremum[V any](seq iter.Seq[V], size int, dominated func(V, V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var deque []Pair[int, V]
+		i := 0
+		for v := range seq {
+			for len(deque) > 0 && dominated(deque[len(deque)-1].Second, v) {
+				deque = deque[:len(deque)-1]
+			}
+			deque = append(deque, Pair[int, V]{First: i, Second: v})
+
+			if deque[0].First <= i-size {
+				deque = deque[1:]
+			}
+
+			if i >= size-1 {
+				if !yield(deque[0].Second) {
+					return
+				}
+			}
+			i++
+		}
+	}
+}
+
+// BatchTimed returns an iterator that groups values from seq into batches, yielding each batch as soon as it reaches
+// maxSize values, or as soon as maxWait has elapsed since the first value of the batch was received.
+// seq is run in a separate goroutine, which exits as soon as seq produces its next value if the consumer stops early.
+// BatchTimed panics if maxSize is not strictly positive.
+func BatchTimed[V any](seq iter.Seq[V], maxSize int, maxWait time.Duration) iter.Seq[[]V] {
+	if maxSize <= 0 {
+		panic("itertools: BatchTimed maxSize must be > 0")
+	}
+
+	return func(yield func([]V) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch := ToChannelContext(ctx, seq, 0)
+		var batch []V
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					if len(batch) > 0 {
+						yield(batch)
+					}
+					return
+				}
+
+				if len(batch) == 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+				batch = append(batch, v)
+				if len(batch) < maxSize {
+					continue
+				}
+				timer.Stop()
+			case <-timeout:
+			}
+
+			timeout = nil
+			if !yield(batch) {
+				return
+			}
+			batch = nil
+		}
+	}
+}
+
+// DedupBy returns an iterator that will yield values from seq, skipping values whose key is equal to the key of
+// the previous value. In other words, only the first value of each run of values sharing the same key is yielded.
+func DedupBy[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var lastK K
+		first := true
+		for v := range seq {
+			k := key(v)
+			if !first && k == lastK {
+				continue
+			}
+			lastK = k
+			first = false
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ForEachIndexed calls f on each value yielded by seq, along with its zero-based index.
+func ForEachIndexed[V any](seq iter.Seq[V], f func(int, V)) {
+	i := 0
+	for v := range seq {
+		f(i, v)
+		i++
+	}
+}
+
+// ForEachIndexed2 calls f on each pair yielded by seq, along with its zero-based index.
+// It is a specialization of ForEachIndexed for when seq is an iter.Seq2 iterator.
+func ForEachIndexed2[K, V any](seq iter.Seq2[K, V], f func(int, K, V)) {
+	i := 0
+	for k, v := range seq {
+		f(i, k, v)
+		i++
+	}
+}
+
+// AppendTo appends the values yielded by seq to dst, and returns the extended slice.
+// It is equivalent to slices.AppendSeq, with arguments ordered like those of append.
+func AppendTo[V any](dst []V, seq iter.Seq[V]) []V {
+	return slices.AppendSeq(dst, seq)
+}
+
+// SplitBefore returns an iterator that groups consecutive values from seq into slices and yields them.
+// A new group is started right before each value that passes p.
+func SplitBefore[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var vs []V
+		for v := range seq {
+			if p(v) && len(vs) > 0 {
+				if !yield(vs) {
+					return
+				}
+				vs = nil
+			}
+			vs = append(vs, v)
+		}
+
+		if len(vs) > 0 {
+			yield(vs)
+		}
+	}
+}
+
+// SplitAfter returns an iterator that groups consecutive values from seq into slices and yields them.
+// The current group is ended right after each value that passes p.
+func SplitAfter[V any](seq iter.Seq[V], p func(V) bool) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var vs []V
+		for v := range seq {
+			vs = append(vs, v)
+			if p(v) {
+				if !yield(vs) {
+					return
+				}
+				vs = nil
+			}
+		}
+
+		if len(vs) > 0 {
+			yield(vs)
+		}
+	}
+}
+
+// Demux returns an iterator that routes values from seq according to key, and yields each distinct key along with
+// an iterator over the values that map to it, in order of first appearance of the keys.
+// Since seq can only be iterated over once, Demux is not lazy: all the values from seq are buffered per key before
+// the first key is yielded. Use DemuxChan to process values concurrently, without buffering them all.
+func Demux[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq2[K, iter.Seq[V]] {
+	return func(yield func(K, iter.Seq[V]) bool) {
+		var keys []K
+		groups := make(map[K][]V)
+		for v := range seq {
+			k := key(v)
+			if _, ok := groups[k]; !ok {
+				keys = append(keys, k)
+			}
+			groups[k] = append(groups[k], v)
+		}
+
+		for _, k := range keys {
+			if !yield(k, FromSlice(groups[k])) {
+				return
+			}
+		}
+	}
+}
+
+// DemuxChan routes values from seq according to key, sending them to a channel per distinct key.
+// When a key is first encountered, its channel is created with the given buffer size, and handle is called with the
+// key and the channel in a new goroutine. Channels are closed once seq is exhausted, and DemuxChan returns once all
+// calls to handle have returned. Handlers must drain their channel, otherwise routing blocks.
+func DemuxChan[V any, K comparable](seq iter.Seq[V], key func(V) K, buffer int, handle func(K, <-chan V)) {
+	var wg sync.WaitGroup
+	chans := make(map[K]chan V)
+	for v := range seq {
+		k := key(v)
+		ch, ok := chans[k]
+		if !ok {
+			ch = make(chan V, buffer)
+			chans[k] = ch
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				handle(k, ch)
+			}()
+		}
+		ch <- v
+	}
+
+	for _, ch := range chans {
+		close(ch)
+	}
+	wg.Wait()
+}
+
+// Transpose returns an iterator that treats the slices yielded by rows as the rows of a matrix, and yields its
+// columns as fresh slices. If rows have different lengths, columns are truncated to the length of the shortest row.
+// Transpose is not lazy: all the rows are collected before the first column is yielded.
+func Transpose[V any](rows iter.Seq[[]V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var matrix [][]V
+		width := 0
+		for row := range rows {
+			if len(matrix) == 0 || len(row) < width {
+				width = len(row)
+			}
+			matrix = append(matrix, row)
+		}
+
+		for j := range width {
+			col := make([]V, len(matrix))
+			for i, row := range matrix {
+				col[i] = row[j]
+			}
+
+			if !yield(col) {
+				return
+			}
+		}
+	}
+}
+
+// SumBy returns the sum of the values obtained by applying f to each value yielded by seq.
+func SumBy[V any, N Numeric](seq iter.Seq[V], f func(V) N) N {
+	var sum N
+	for v := range seq {
+		sum += f(v)
+	}
+	return sum
+}
+
+// CountBy counts the values yielded by seq per key, as returned by key.
+func CountBy[V any, K comparable](seq iter.Seq[V], key func(V) K) map[K]int {
+	_, counts := tally(Map(seq, key))
+	return counts
+}
+
+// RunningCountBy returns an iterator that will yield each value from seq, paired with the number of values yielded
+// so far that share its key, as returned by key, including itself: the first value of each key is paired with 1.
+func RunningCountBy[V any, K comparable](seq iter.Seq[V], key func(V) K) iter.Seq2[V, int] {
+	return func(yield func(V, int) bool) {
+		counts := make(map[K]int)
+		for v := range seq {
+			k := key(v)
+			counts[k]++
+			if !yield(v, counts[k]) {
+				return
+			}
+		}
+	}
+}
+
+// PartitionSeq returns two iterators that will respectively yield the values from seq that pass p, and those that
+// do not pass p. Both iterators lazily pull values from seq, which is shared between them: values that are pulled by
+// one iterator but belong to the other one are buffered until the other one requests them, so consuming the two
+// iterators unevenly may buffer an arbitrary number of values.
+// Each of the returned iterators can only be iterated over once, and they must not be iterated over concurrently.
+// Resources held by seq are only released once seq is exhausted.
+func PartitionSeq[V any](seq iter.Seq[V], p func(V) bool) (matched, unmatched iter.Seq[V]) {
+	var next func() (V, bool)
+	var stop func()
+	exhausted := false
+	var pending [2][]V
+
+	side := func(want int) iter.Seq[V] {
+		return func(yield func(V) bool) {
+			for {
+				if len(pending[want]) > 0 {
+					v := pending[want][0]
+					pending[want] = pending[want][1:]
+					if !yield(v) {
+						return
+					}
+					continue
+				}
+
+				if exhausted {
+					return
+				}
+				if next == nil {
+					next, stop = iter.Pull(seq)
+				}
+				v, ok := next()
+				if !ok {
+					exhausted = true
+					stop()
+					return
+				}
+
+				got := 1
+				if p(v) {
+					got = 0
+				}
+				if got != want {
+					pending[got] = append(pending[got], v)
+					continue
+				}
+
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+
+	return side(0), side(1)
+}
+
+// Replace returns an iterator that will yield values from seq, replacing the values equal to old with new.
+func Replace[V comparable](seq iter.Seq[V], old, new V) iter.Seq[V] {
+	return ReplaceFunc(seq, func(v V) bool { return v == old }, func(_ V) V { return new })
+}
+
+// ReplaceFunc returns an iterator that will yield values from seq, replacing the values that pass match with the
+// result of calling replacement on them.
+func ReplaceFunc[V any](seq iter.Seq[V], match func(V) bool, replacement func(V) V) iter.Seq[V] {
+	return Map(seq, func(v V) V {
+		if match(v) {
+			return replacement(v)
+		}
+		return v
+	})
+}
+
+// LongestRun returns the value forming the longest run of equal consecutive values yielded by seq, along with the
+// length of that run. If there is more than one longest run, LongestRun returns the first one.
+// If no values are yielded by seq, a zero-value is returned and ok is false.
+func LongestRun[V comparable](seq iter.Seq[V]) (value V, length int, ok bool) {
+	for v, n := range ChunkCounts(seq, Identity[V]) {
+		if n > length {
+			value, length, ok = v, n, true
+		}
+	}
+	return value, length, ok
+}
+
+// Normalize returns an iterator that will yield values from seq scaled to the [0, 1] range, according to the
+// minimum and maximum values yielded by seq. If all the values are equal, zeros are yielded.
+// Normalize is not lazy: all the values from seq are collected before the first one is yielded.
+func Normalize[V Numeric](seq iter.Seq[V]) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		vs := slices.Collect(seq)
+		if len(vs) == 0 {
+			return
+		}
+
+		lo, hi := float64(slices.Min(vs)), float64(slices.Max(vs))
+		for _, v := range vs {
+			x := 0.0
+			if hi > lo {
+				x = (float64(v) - lo) / (hi - lo)
+			}
+
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// DropNth returns an iterator that will yield values from seq, except for every n-th value.
+// In other words, values at indices n-1, 2n-1, 3n-1, etc. are dropped.
+// DropNth panics if n is not strictly positive.
+func DropNth[V any, N Integer](seq iter.Seq[V], n N) iter.Seq[V] {
+	if n <= 0 {
+		panic("itertools: DropNth n must be > 0")
+	}
+
+	return func(yield func(V) bool) {
+		count := N(0)
+		for v := range seq {
+			count++
+			if count == n {
+				count = 0
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Fuse returns an iterator yielding values from seq, which yields no values anymore once it has been iterated over,
+// whether seq was exhausted or the consumer stopped early. This makes iterators that misbehave when iterated over
+// multiple times well-behaved.
+// The returned iterator must not be iterated over concurrently.
+func Fuse[V any](seq iter.Seq[V]) iter.Seq[V] {
+	done := false
+	return func(yield func(V) bool) {
+		if done {
+			return
+		}
+		defer func() {
+			done = true
+		}()
+
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// CollectCapped collects at most limit values yielded by seq into a new slice, which protects against draining
+// unbounded iterators into memory. The second return value reports whether seq had more than limit values, in which
+// case seq was stopped after producing one value past the limit.
+// If limit is negative, no values are collected.
+func CollectCapped[V any](seq iter.Seq[V], limit int) ([]V, bool) {
+	var vs []V
+	for v := range seq {
+		if len(vs) >= limit {
+			return vs, true
+		}
+		vs = append(vs, v)
+	}
+	return vs, false
+}
+
+// CollectKeysValues collects the pairs yielded by seq into two new slices, holding respectively the keys and the
+// values of the pairs, in iteration order.
+func CollectKeysValues[K, V any](seq iter.Seq2[K, V]) (keys []K, values []V) {
+	for k, v := range seq {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	return keys, values
+}
+
+// UniqueWindow returns an iterator that will yield values from seq, skipping values that are equal to one of the
+// last window yielded values. Memory usage is bounded by window, unlike global deduplication.
+// If window is not strictly positive, all the values are yielded.
+func UniqueWindow[V comparable](seq iter.Seq[V], window int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		recent := make([]V, 0, max(window, 0))
+		seen := make(map[V]struct{})
+		i := 0
+		for v := range seq {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+
+			if window > 0 {
+				if len(recent) < window {
+					recent = append(recent, v)
+				} else {
+					delete(seen, recent[i])
+					recent[i] = v
+					i = (i + 1) % window
+				}
+				seen[v] = struct{}{}
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// UniqueLRU returns an iterator that will yield values from seq, skipping values that are among the capacity most
+// recently seen ones. Unlike UniqueWindow, seeing a skipped value again refreshes it, so it is only forgotten after
+// capacity other distinct values have been seen since. onEvict, if not nil, is called with each forgotten value.
+// If capacity is not strictly positive, all the values are yielded and onEvict is never called.
+func UniqueLRU[V comparable](seq iter.Seq[V], capacity int, onEvict func(V)) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		recent := list.New()
+		seen := make(map[V]*list.Element)
+		for v := range seq {
+			if e, ok := seen[v]; ok {
+				recent.MoveToFront(e)
+				continue
+			}
+
+			if capacity > 0 {
+				if recent.Len() == capacity {
+					evicted := recent.Remove(recent.Back()).(V)
+					delete(seen, evicted)
+					if onEvict != nil {
+						onEvict(evicted)
+					}
+				}
+				seen[v] = recent.PushFront(v)
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// WindowSum returns an iterator that will yield the sum of each overlapping window of size consecutive values from
+// seq. The sum is maintained by subtracting outgoing values and adding incoming ones rather than summing each window,
+// and is recomputed from scratch every size values so that rounding errors do not accumulate for floating-point
+// values.
+// No values are yielded if seq yields fewer than size values.
+// WindowSum panics if size is not strictly positive.
+func WindowSum[V Numeric, N Integer](seq iter.Seq[V], size N) iter.Seq[V] {
+	if size <= 0 {
+		panic("itertools: WindowSum size must be > 0")
+	}
+
+	return func(yield func(V) bool) {
+		n := int(size)
+		buf := make([]V, 0, n)
+		var sum V
+		i := 0
+		for v := range seq {
+			if len(buf) < n {
+				buf = append(buf, v)
+				sum += v
+				if len(buf) < n {
+					continue
+				}
+			} else {
+				sum -= buf[i]
+				sum += v
+				buf[i] = v
+				i = (i + 1) % n
+				if i == 0 {
+					sum = 0
+					for _, w := range buf {
+						sum += w
+					}
+				}
+			}
+
+			if !yield(sum) {
+				return
+			}
+		}
+	}
+}
+
+// CollectBytes collects the bytes yielded by seq into a new slice.
+func CollectBytes(seq iter.Seq[byte]) []byte {
+	var buf bytes.Buffer
+	for b := range seq {
+		buf.WriteByte(b)
+	}
+	return buf.Bytes()
+}
+
+// writeToBufferSize is the size of the chunks written by WriteTo.
+const writeToBufferSize = 4096
+
+// WriteTo writes the bytes yielded by seq to w, and returns the number of bytes written.
+// Bytes are written in chunks rather than one at a time. WriteTo stops at the first error returned by w.
+func WriteTo(seq iter.Seq[byte], w io.Writer) (int, error) {
+	buf := make([]byte, 0, writeToBufferSize)
+	written := 0
+	for b := range seq {
+		buf = append(buf, b)
+		if len(buf) == cap(buf) {
+			n, err := w.Write(buf)
+			written += n
+			if err != nil {
+				return written, err
+			}
+			buf = buf[:0]
+		}
+	}
+
+	if len(buf) > 0 {
+		n, err := w.Write(buf)
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// Peekable wraps an iterator to allow looking at its next value without consuming it.
+// A Peekable pulls values from the underlying iterator, and must be stopped using Stop once it is no longer needed.
+type Peekable[V any] struct {
+	next   func() (V, bool)
+	stop   func()
+	peeked bool
+	v      V
+	ok     bool
+}
+
+// NewPeekable returns a Peekable pulling values from seq.
+func NewPeekable[V any](seq iter.Seq[V]) *Peekable[V] {
+	next, stop := iter.Pull(seq)
+	return &Peekable[V]{next: next, stop: stop}
+}
+
+// Peek returns the next value of p without consuming it.
+// If p is exhausted, a zero-value is returned and the second return value is false.
+func (p *Peekable[V]) Peek() (V, bool) {
+	if !p.peeked {
+		p.v, p.ok = p.next()
+		p.peeked = true
+	}
+	return p.v, p.ok
+}
+
+// Next consumes and returns the next value of p.
+// If p is exhausted, a zero-value is returned and the second return value is false.
+func (p *Peekable[V]) Next() (V, bool) {
+	v, ok := p.Peek()
+	if ok {
+		var zero V
+		p.peeked = false
+		p.v = zero
+	}
+	return v, ok
+}
+
+// Stop stops the underlying iterator of p.
+func (p *Peekable[V]) Stop() {
+	p.stop()
+}
+
+// TakeWhilePeek consumes values from p as long as they pass pred, and returns them in a new slice.
+// The first value that does not pass pred is not consumed, and remains available from p.
+func TakeWhilePeek[V any](p *Peekable[V], pred func(V) bool) []V {
+	var vs []V
+	for v, ok := p.Peek(); ok && pred(v); v, ok = p.Peek() {
+		vs = append(vs, v)
+		p.Next()
+	}
+	return vs
+}
+
+// DropWhilePeek consumes values from p as long as they pass pred, and returns the number of values consumed.
+// The first value that does not pass pred is not consumed, and remains available from p.
+func DropWhilePeek[V any](p *Peekable[V], pred func(V) bool) int {
+	n := 0
+	for v, ok := p.Peek(); ok && pred(v); v, ok = p.Peek() {
+		n++
+		p.Next()
+	}
+	return n
+}
+
+// Identity returns v.
+func Identity[V any](v V) V {
+	return v
+}
+
+// Const returns a function that ignores its argument and always returns w.
+func Const[V, W any](w W) func(V) W {
+	return func(_ V) W {
+		return w
+	}
+}
+
+// Not returns a predicate that passes values that do not pass p.
+func Not[V any](p func(V) bool) func(V) bool {
+	return func(v V) bool {
+		return !p(v)
+	}
+}
+
+// CompareBy returns a comparison function, suitable for functions such as MinFunc or IsSortedFunc, that compares
+// values according to the keys returned by key.
+func CompareBy[V any, K cmp.Ordered](key func(V) K) func(V, V) int {
+	return func(a, b V) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ThenBy returns a comparison function that compares values using first, and then using second if they are equal
+// according to first. Calls to ThenBy can be nested to compare values using any number of comparison functions.
+func ThenBy[V any](first, second func(V, V) int) func(V, V) int {
+	return func(a, b V) int {
+		if c := first(a, b); c != 0 {
+			return c
+		}
+		return second(a, b)
+	}
+}
+
+// Metered returns an iterator that will yield values from seq unchanged, calling onYield with the zero-based index
+// and the value right before each value is passed to the consumer. It is intended for instrumentation, such as
+// progress reporting.
+func Metered[V any](seq iter.Seq[V], onYield func(index int, v V)) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		i := 0
+		for v := range seq {
+			onYield(i, v)
+			if !yield(v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Differences returns an iterator that will yield the differences between consecutive values from seq, i.e.
+// v1-v0, v2-v1, v3-v2, etc. No values are yielded if seq yields fewer than two values.
+func Differences[V Numeric](seq iter.Seq[V]) iter.Seq[V] {
+	return WindowReduce(seq, 2, func(pair []V) V {
+		return pair[1] - pair[0]
+	})
+}
+
+// CumulativeSum returns an iterator that will yield the running sums of values from seq.
+func CumulativeSum[V Numeric](seq iter.Seq[V]) iter.Seq[V] {
+	return Accumulate(seq, func(a, b V) V { return a + b })
+}
+
+// CumulativeProduct returns an iterator that will yield the running products of values from seq.
+func CumulativeProduct[V Numeric](seq iter.Seq[V]) iter.Seq[V] {
+	return Accumulate(seq, func(a, b V) V { return a * b })
+}
+
+// CumulativeMax returns an iterator that will yield the running maxima of values from seq.
+func CumulativeMax[V cmp.Ordered](seq iter.Seq[V]) iter.Seq[V] {
+	return Accumulate(seq, func(a, b V) V { return max(a, b) })
+}
+
+// CumulativeMin returns an iterator that will yield the running minima of values from seq.
+func CumulativeMin[V cmp.Ordered](seq iter.Seq[V]) iter.Seq[V] {
+	return Accumulate(seq, func(a, b V) V { return min(a, b) })
+}
@@ -1,12 +1,21 @@
 package itertools_test
 
 import (
+	"bytes"
+	"context"
+	"io"
 	"iter"
 	"maps"
+	"math"
+	"math/rand/v2"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,10 +23,6 @@ import (
 	"github.com/doom/go-itertools"
 )
 
-func Empty[V any]() iter.Seq[V] {
-	return func(yield func(V) bool) {}
-}
-
 func Empty2[V, W any]() iter.Seq2[V, W] {
 	return func(yield func(V, W) bool) {}
 }
@@ -32,6 +37,17 @@ func IntRange(a, b int) iter.Seq[int] {
 	}
 }
 
+func TestItertools_Empty(t *testing.T) {
+	assert.Equal(t, []int(nil), slices.Collect(itertools.Empty[int]()))
+	assert.Equal(t, []int{0, 1}, slices.Collect(itertools.Chain(itertools.Empty[int](), IntRange(0, 2))))
+}
+
+func TestItertools_Once(t *testing.T) {
+	assert.Equal(t, []string{"a"}, slices.Collect(itertools.Once("a")))
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(itertools.Chain(itertools.Once(0), IntRange(1, 3))))
+	assert.Equal(t, []int{7}, slices.Collect(itertools.Take(itertools.Once(7), 5)))
+}
+
 func TestItertools_FromSlice(t *testing.T) {
 	is := itertools.FromSlice([]int{0, 1, 2, 3, 4})
 	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
@@ -40,12 +56,189 @@ func TestItertools_FromSlice(t *testing.T) {
 	assert.Equal(t, []string(nil), slices.Collect(ss))
 }
 
+func TestItertools_FromRunes(t *testing.T) {
+	rs := itertools.FromRunes("héllo, 世界")
+	assert.Equal(t, []rune{'h', 'é', 'l', 'l', 'o', ',', ' ', '世', '界'}, slices.Collect(rs))
+
+	rs = itertools.FromRunes("a\xffb")
+	assert.Equal(t, []rune{'a', utf8.RuneError, 'b'}, slices.Collect(rs))
+
+	rs = itertools.FromRunes("")
+	assert.Equal(t, []rune(nil), slices.Collect(rs))
+}
+
+func TestItertools_FromBytes(t *testing.T) {
+	bs := itertools.FromBytes([]byte("héllo"))
+	assert.Equal(t, []byte("héllo"), slices.Collect(bs))
+
+	bs = itertools.FromBytes(nil)
+	assert.Equal(t, []byte(nil), slices.Collect(bs))
+}
+
+func TestItertools_FromChannel(t *testing.T) {
+	ch := make(chan int, 5)
+	for i := range 5 {
+		ch <- i
+	}
+	close(ch)
+	is := itertools.FromChannel(ch)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	ch = make(chan int, 5)
+	for i := range 5 {
+		ch <- i
+	}
+	close(ch)
+	for v := range itertools.FromChannel(ch) {
+		if v == 1 {
+			break
+		}
+	}
+	assert.Equal(t, 3, len(ch))
+
+	ch = make(chan int)
+	close(ch)
+	is = itertools.FromChannel(ch)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_ToChannel(t *testing.T) {
+	ch := itertools.ToChannel(IntRange(0, 5), 0)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(itertools.FromChannel(ch)))
+
+	ch = itertools.ToChannel(IntRange(0, 5), 5)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(itertools.FromChannel(ch)))
+
+	ch = itertools.ToChannel(itertools.Empty[int](), 0)
+	assert.Equal(t, []int(nil), slices.Collect(itertools.FromChannel(ch)))
+}
+
+func TestItertools_ToChannelContext(t *testing.T) {
+	ch := itertools.ToChannelContext(context.Background(), IntRange(0, 5), 1)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(itertools.FromChannel(ch)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch = itertools.ToChannelContext(ctx, itertools.Repeat(1), 0)
+	assert.Equal(t, 1, <-ch)
+	cancel()
+	for range ch {
+	}
+
+	pulled := 0
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	ch = itertools.ToChannelContext(ctx, itertools.WithFunc(func() int {
+		pulled++
+		return 1
+	}), 10)
+	assert.Equal(t, []int(nil), slices.Collect(itertools.FromChannel(ch)))
+	assert.Equal(t, 1, pulled)
+}
+
+func TestItertools_Buffer(t *testing.T) {
+	is := itertools.Buffer(IntRange(0, 100), 10)
+	assert.Equal(t, slices.Collect(IntRange(0, 100)), slices.Collect(is))
+
+	is = itertools.Buffer(IntRange(0, 5), 0)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	produced := make(chan int, 1000)
+	is = itertools.Buffer(itertools.WithFunc(func() int {
+		time.Sleep(10 * time.Microsecond)
+		produced <- 1
+		return 1
+	}), 64)
+	assert.Equal(t, []int{1}, slices.Collect(itertools.Take(is, 1)))
+	stopped := len(produced)
+	time.Sleep(10 * time.Millisecond)
+	assert.LessOrEqual(t, len(produced), stopped+1)
+
+	is = itertools.Buffer(itertools.Empty[int](), 3)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_WithContext(t *testing.T) {
+	is := itertools.WithContext(context.Background(), IntRange(0, 5))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	i := -1
+	is = itertools.WithContext(ctx, itertools.WithFunc(func() int {
+		i++
+		if i == 3 {
+			cancel()
+		}
+		return i
+	}))
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+
+	is = itertools.WithContext(ctx, IntRange(0, 5))
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_Interruptible(t *testing.T) {
+	is := itertools.Interruptible(IntRange(0, 5), make(chan struct{}))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	stop := make(chan struct{})
+	i := -1
+	is = itertools.Interruptible(itertools.WithFunc(func() int {
+		i++
+		if i == 3 {
+			close(stop)
+		}
+		return i
+	}), stop)
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+
+	stop = make(chan struct{}, 1)
+	stop <- struct{}{}
+	is = itertools.Interruptible(IntRange(0, 5), stop)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_Of(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(itertools.Of(1, 2, 3)))
+	assert.Equal(t, []string{"a"}, slices.Collect(itertools.Of("a")))
+	assert.Equal(t, []int(nil), slices.Collect(itertools.Of[int]()))
+}
+
 func TestItertools_Map(t *testing.T) {
 	ss := itertools.Map(IntRange(0, 5), strconv.Itoa)
 	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, slices.Collect(ss))
 
-	ss = itertools.Map(Empty[int](), strconv.Itoa)
+	ss = itertools.Map(itertools.Empty[int](), strconv.Itoa)
+	assert.Equal(t, []string(nil), slices.Collect(ss))
+}
+
+func TestItertools_ParallelMap(t *testing.T) {
+	is := itertools.ParallelMap(IntRange(0, 100), 4, func(v int) int {
+		time.Sleep(time.Duration(v%3) * time.Millisecond)
+		return v * v
+	})
+	assert.Equal(t, slices.Collect(itertools.Map(IntRange(0, 100), func(v int) int { return v * v })), slices.Collect(is))
+
+	ss := itertools.ParallelMap(itertools.Repeat(1), 3, strconv.Itoa)
+	assert.Equal(t, []string{"1", "1", "1"}, slices.Collect(itertools.Take(ss, 3)))
+
+	ss = itertools.ParallelMap(itertools.Empty[int](), 3, strconv.Itoa)
 	assert.Equal(t, []string(nil), slices.Collect(ss))
+
+	var started, finished atomic.Int32
+	slow := itertools.ParallelMap(itertools.Repeat(1), 4, func(v int) int {
+		started.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		finished.Add(1)
+		return v
+	})
+	for range slow {
+		break
+	}
+	assert.Equal(t, started.Load(), finished.Load())
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, started.Load(), finished.Load())
+
+	assert.Panics(t, func() { itertools.ParallelMap(itertools.Empty[int](), 0, strconv.Itoa) })
 }
 
 func TestItertools_MapFromSeq2(t *testing.T) {
@@ -60,10 +253,83 @@ func TestItertools_MapToSeq2(t *testing.T) {
 	is := itertools.MapToSeq2(IntRange(0, 5), func(v int) (string, int) { return strconv.Itoa(v), v })
 	assert.Equal(t, map[string]int{"0": 0, "1": 1, "2": 2, "3": 3, "4": 4}, maps.Collect(is))
 
-	is = itertools.MapToSeq2(Empty[int](), func(v int) (string, int) { return strconv.Itoa(v), v })
+	is = itertools.MapToSeq2(itertools.Empty[int](), func(v int) (string, int) { return strconv.Itoa(v), v })
 	assert.Equal(t, map[string]int{}, maps.Collect(is))
 }
 
+func TestItertools_KeyBy(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+
+	kvs := itertools.KeyBy(itertools.Of(user{1, "alice"}, user{2, "bob"}), func(u user) int { return u.id })
+	assert.Equal(t, map[int]user{1: {1, "alice"}, 2: {2, "bob"}}, maps.Collect(kvs))
+
+	kvs = itertools.KeyBy(itertools.Empty[user](), func(u user) int { return u.id })
+	assert.Equal(t, map[int]user{}, maps.Collect(kvs))
+}
+
+func TestItertools_TryMap(t *testing.T) {
+	is := itertools.TryMap(itertools.FromSlice([]string{"0", "1", "a", "3"}), strconv.Atoi)
+	var errs []bool
+	for _, err := range is {
+		errs = append(errs, err != nil)
+	}
+	assert.Equal(t, []bool{false, false, true, false}, errs)
+
+	is = itertools.TryMap(itertools.Empty[string](), strconv.Atoi)
+	assert.Equal(t, map[int]error{}, maps.Collect(is))
+}
+
+func TestItertools_CollectErr(t *testing.T) {
+	is, err := itertools.CollectErr(itertools.TryMap(itertools.FromSlice([]string{"0", "1", "2"}), strconv.Atoi))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, is)
+
+	is, err = itertools.CollectErr(itertools.TryMap(itertools.FromSlice([]string{"0", "a", "2"}), strconv.Atoi))
+	require.Error(t, err)
+	assert.Equal(t, []int{0}, is)
+
+	is, err = itertools.CollectErr(itertools.TryMap(itertools.Empty[string](), strconv.Atoi))
+	require.NoError(t, err)
+	assert.Equal(t, []int(nil), is)
+}
+
+func TestItertools_Retry(t *testing.T) {
+	calls := 0
+	flaky := func() iter.Seq2[int, error] {
+		calls++
+		inputs := []string{"0", "1", "2"}
+		if calls < 3 {
+			inputs = []string{"0", "a"}
+		}
+		return itertools.TryMap(itertools.FromSlice(inputs), strconv.Atoi)
+	}
+
+	is, err := itertools.CollectErr(itertools.Retry(flaky, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 0, 1, 2}, is)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	is, err = itertools.CollectErr(itertools.Retry(flaky, 2))
+	require.Error(t, err)
+	assert.Equal(t, []int{0, 0}, is)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	_, err = itertools.CollectErr(itertools.Retry(flaky, 0))
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	for range itertools.Retry(flaky, 3) {
+		break
+	}
+	assert.Equal(t, 1, calls)
+}
+
 func TestItertools_Filter(t *testing.T) {
 	ss := itertools.Filter(IntRange(0, 5), func(i int) bool {
 		return i%2 == 0
@@ -73,7 +339,7 @@ func TestItertools_Filter(t *testing.T) {
 	ss = itertools.Filter(IntRange(0, 5), func(i int) bool { return false })
 	assert.Equal(t, []int(nil), slices.Collect(ss))
 
-	ss = itertools.Filter(Empty[int](), func(_ int) bool { return true })
+	ss = itertools.Filter(itertools.Empty[int](), func(_ int) bool { return true })
 	assert.Equal(t, []int(nil), slices.Collect(ss))
 }
 
@@ -88,12 +354,70 @@ func TestItertools_Reduce(t *testing.T) {
 	}, 123)
 	assert.Equal(t, 123+0+1+2+3+4, n)
 
-	n = itertools.Reduce(Empty[int](), func(a, b int) int {
+	n = itertools.Reduce(itertools.Empty[int](), func(a, b int) int {
 		return a + b
 	}, 123)
 	assert.Equal(t, 123, n)
 }
 
+func TestItertools_Reduce1(t *testing.T) {
+	n, ok := itertools.Reduce1(IntRange(1, 5), func(a, b int) int { return a * b })
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 1*2*3*4, n)
+
+	s, ok := itertools.Reduce1(itertools.FromSlice([]string{"a"}), func(a, b string) string { return a + "," + b })
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "a", s)
+
+	_, ok = itertools.Reduce1(itertools.Empty[int](), func(a, b int) int { return a * b })
+	assert.Equal(t, false, ok)
+}
+
+func TestItertools_Reduce2(t *testing.T) {
+	n := itertools.Reduce2(itertools.FromMap(map[int]int{1: 2, 3: 4, 5: 6}), func(acc, k, v int) int {
+		return acc + k*v
+	}, 0)
+	assert.Equal(t, 1*2+3*4+5*6, n)
+
+	n = itertools.Reduce2(Empty2[int, int](), func(acc, k, v int) int {
+		return acc + k*v
+	}, 123)
+	assert.Equal(t, 123, n)
+}
+
+func TestItertools_TryReduce(t *testing.T) {
+	sum := func(a int, s string) (int, error) {
+		b, err := strconv.Atoi(s)
+		return a + b, err
+	}
+
+	n, err := itertools.TryReduce(itertools.FromSlice([]string{"1", "2", "3"}), sum, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1+2+3, n)
+
+	n, err = itertools.TryReduce(itertools.FromSlice([]string{"1", "2", "a", "3"}), sum, 0)
+	require.Error(t, err)
+	assert.Equal(t, 1+2, n)
+
+	n, err = itertools.TryReduce(itertools.Empty[string](), sum, 123)
+	require.NoError(t, err)
+	assert.Equal(t, 123, n)
+}
+
+func TestItertools_Accumulate(t *testing.T) {
+	is := itertools.Accumulate(IntRange(1, 6), func(a, b int) int { return a + b })
+	assert.Equal(t, []int{1, 3, 6, 10, 15}, slices.Collect(is))
+
+	is = itertools.Accumulate(itertools.FromSlice([]int{3, 1, 4, 1, 5}), func(a, b int) int { return max(a, b) })
+	assert.Equal(t, []int{3, 3, 4, 4, 5}, slices.Collect(is))
+
+	is = itertools.Accumulate(IntRange(7, 8), func(a, b int) int { return a + b })
+	assert.Equal(t, []int{7}, slices.Collect(is))
+
+	is = itertools.Accumulate(itertools.Empty[int](), func(a, b int) int { return a + b })
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
 func TestItertools_TakeWhile(t *testing.T) {
 	is := itertools.TakeWhile(IntRange(0, 5), func(i int) bool { return i < 3 })
 	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
@@ -104,10 +428,40 @@ func TestItertools_TakeWhile(t *testing.T) {
 	is = itertools.TakeWhile(IntRange(0, 5), func(i int) bool { return false })
 	assert.Equal(t, []int(nil), slices.Collect(is))
 
-	ss := itertools.TakeWhile(Empty[string](), func(i string) bool { return true })
+	ss := itertools.TakeWhile(itertools.Empty[string](), func(i string) bool { return true })
+	assert.Equal(t, []string(nil), slices.Collect(ss))
+}
+
+func TestItertools_TakeUntil(t *testing.T) {
+	is := itertools.TakeUntil(IntRange(0, 5), func(i int) bool { return i == 2 })
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+
+	is = itertools.TakeUntil(IntRange(0, 5), func(i int) bool { return false })
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	is = itertools.TakeUntil(IntRange(0, 5), func(i int) bool { return true })
+	assert.Equal(t, []int{0}, slices.Collect(is))
+
+	bs := itertools.TakeUntil(itertools.FromSlice([]byte("ab\ncd\n")), func(b byte) bool { return b == '\n' })
+	assert.Equal(t, []byte("ab\n"), slices.Collect(bs))
+
+	ss := itertools.TakeUntil(itertools.Empty[string](), func(i string) bool { return true })
 	assert.Equal(t, []string(nil), slices.Collect(ss))
 }
 
+func TestItertools_TakeWhile2(t *testing.T) {
+	kvs := itertools.TakeWhile2(itertools.ZipShortest(IntRange(0, 5), itertools.FromSlice([]string{"a", "b", "c", "d", "e"})), func(k int, v string) bool {
+		return k < 2 || v == "c"
+	})
+	assert.Equal(t, map[int]string{0: "a", 1: "b", 2: "c"}, maps.Collect(kvs))
+
+	kvs = itertools.TakeWhile2(itertools.ZipShortest(IntRange(0, 5), itertools.Repeat("a")), func(k int, v string) bool { return false })
+	assert.Equal(t, map[int]string{}, maps.Collect(kvs))
+
+	kvs = itertools.TakeWhile2(Empty2[int, string](), func(k int, v string) bool { return true })
+	assert.Equal(t, map[int]string{}, maps.Collect(kvs))
+}
+
 func TestItertools_Take(t *testing.T) {
 	is := itertools.Take(IntRange(0, 5), 3)
 	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
@@ -118,8 +472,34 @@ func TestItertools_Take(t *testing.T) {
 	is = itertools.Take(IntRange(0, 5), 0)
 	assert.Equal(t, []int(nil), slices.Collect(is))
 
-	ss := itertools.Take(Empty[string](), 5)
+	is = itertools.Take(IntRange(0, 5), -1)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.Take(IntRange(0, 5), uint8(2))
+	assert.Equal(t, []int{0, 1}, slices.Collect(is))
+
+	ss := itertools.Take(itertools.Empty[string](), 5)
 	assert.Equal(t, []string(nil), slices.Collect(ss))
+
+	is = itertools.Take(itertools.Of(0, 1, 2), 2)
+	assert.Equal(t, []int{0, 1}, slices.Collect(is))
+	assert.Equal(t, []int{0, 1}, slices.Collect(is))
+}
+
+func TestItertools_Take2(t *testing.T) {
+	letters := func() iter.Seq2[int, string] {
+		return itertools.ZipShortest(IntRange(0, 5), itertools.FromSlice([]string{"a", "b", "c", "d", "e"}))
+	}
+
+	assert.Equal(t, map[int]string{0: "a", 1: "b"}, maps.Collect(itertools.Take2(letters(), 2)))
+	assert.Equal(t, 5, len(maps.Collect(itertools.Take2(letters(), uint(10)))))
+	assert.Equal(t, map[int]string{}, maps.Collect(itertools.Take2(letters(), 0)))
+	assert.Equal(t, map[int]string{}, maps.Collect(itertools.Take2(letters(), -1)))
+	assert.Equal(t, map[int]string{}, maps.Collect(itertools.Take2(Empty2[int, string](), 2)))
+
+	taken := itertools.Take2(itertools.ZipShortest(itertools.Of(0, 1, 2), itertools.Of("a", "b", "c")), 2)
+	assert.Equal(t, map[int]string{0: "a", 1: "b"}, maps.Collect(taken))
+	assert.Equal(t, map[int]string{0: "a", 1: "b"}, maps.Collect(taken))
 }
 
 func TestItertools_DropWhile(t *testing.T) {
@@ -132,10 +512,26 @@ func TestItertools_DropWhile(t *testing.T) {
 	is = itertools.DropWhile(IntRange(0, 5), func(i int) bool { return false })
 	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
 
-	ss := itertools.DropWhile(Empty[string](), func(i string) bool { return false })
+	ss := itertools.DropWhile(itertools.Empty[string](), func(i string) bool { return false })
 	assert.Equal(t, []string(nil), slices.Collect(ss))
 }
 
+func TestItertools_DropWhile2(t *testing.T) {
+	kvs := itertools.DropWhile2(itertools.ZipShortest(IntRange(0, 5), itertools.FromSlice([]string{"a", "b", "c", "d", "e"})), func(k int, v string) bool {
+		return k < 2 || v == "c"
+	})
+	assert.Equal(t, map[int]string{3: "d", 4: "e"}, maps.Collect(kvs))
+
+	kvs = itertools.DropWhile2(itertools.ZipShortest(IntRange(0, 3), itertools.Repeat("a")), func(k int, v string) bool { return false })
+	assert.Equal(t, map[int]string{0: "a", 1: "a", 2: "a"}, maps.Collect(kvs))
+
+	kvs = itertools.DropWhile2(itertools.ZipShortest(IntRange(0, 3), itertools.Repeat("a")), func(k int, v string) bool { return true })
+	assert.Equal(t, map[int]string{}, maps.Collect(kvs))
+
+	kvs = itertools.DropWhile2(Empty2[int, string](), func(k int, v string) bool { return false })
+	assert.Equal(t, map[int]string{}, maps.Collect(kvs))
+}
+
 func TestItertools_Drop(t *testing.T) {
 	is := itertools.Drop(IntRange(0, 5), 3)
 	assert.Equal(t, []int{3, 4}, slices.Collect(is))
@@ -146,24 +542,85 @@ func TestItertools_Drop(t *testing.T) {
 	is = itertools.Drop(IntRange(0, 5), 0)
 	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
 
-	ss := itertools.Drop(Empty[string](), 0)
+	is = itertools.Drop(IntRange(0, 5), -1)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	is = itertools.Drop(IntRange(0, 5), uint8(2))
+	assert.Equal(t, []int{2, 3, 4}, slices.Collect(is))
+
+	ss := itertools.Drop(itertools.Empty[string](), 0)
 	assert.Equal(t, []string(nil), slices.Collect(ss))
+
+	is = itertools.Drop(itertools.Of(0, 1, 2), 2)
+	assert.Equal(t, []int{2}, slices.Collect(is))
+	assert.Equal(t, []int{2}, slices.Collect(is))
+}
+
+func TestItertools_Drop2(t *testing.T) {
+	letters := func() iter.Seq2[int, string] {
+		return itertools.ZipShortest(IntRange(0, 5), itertools.FromSlice([]string{"a", "b", "c", "d", "e"}))
+	}
+
+	assert.Equal(t, map[int]string{3: "d", 4: "e"}, maps.Collect(itertools.Drop2(letters(), 3)))
+	assert.Equal(t, map[int]string{}, maps.Collect(itertools.Drop2(letters(), uint(10))))
+	assert.Equal(t, 5, len(maps.Collect(itertools.Drop2(letters(), 0))))
+	assert.Equal(t, 5, len(maps.Collect(itertools.Drop2(letters(), -1))))
+	assert.Equal(t, map[int]string{}, maps.Collect(itertools.Drop2(Empty2[int, string](), 2)))
+
+	dropped := itertools.Drop2(itertools.ZipShortest(itertools.Of(0, 1, 2), itertools.Of("a", "b", "c")), 2)
+	assert.Equal(t, map[int]string{2: "c"}, maps.Collect(dropped))
+	assert.Equal(t, map[int]string{2: "c"}, maps.Collect(dropped))
+}
+
+func TestItertools_DropLastWhile(t *testing.T) {
+	ss := itertools.DropLastWhile(itertools.FromSlice([]string{"", "a", "", "b", "", ""}), func(s string) bool { return s == "" })
+	assert.Equal(t, []string{"", "a", "", "b"}, slices.Collect(ss))
+
+	is := itertools.DropLastWhile(IntRange(0, 5), func(i int) bool { return true })
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.DropLastWhile(IntRange(0, 5), func(i int) bool { return false })
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	is = itertools.DropLastWhile(IntRange(0, 5), func(i int) bool { return i%2 == 0 })
+	assert.Equal(t, []int{0, 1, 2, 3}, slices.Collect(is))
+
+	is = itertools.DropLastWhile(itertools.Empty[int](), func(i int) bool { return false })
+	assert.Equal(t, []int(nil), slices.Collect(is))
 }
 
 func TestItertools_Chain(t *testing.T) {
-	is := itertools.Chain(Empty[int](), Empty[int]())
+	is := itertools.Chain(itertools.Empty[int](), itertools.Empty[int]())
 	assert.Equal(t, []int(nil), slices.Collect(is))
 
-	is = itertools.Chain(Empty[int](), IntRange(0, 5))
+	is = itertools.Chain(itertools.Empty[int](), IntRange(0, 5))
 	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
 
-	is = itertools.Chain(IntRange(0, 5), Empty[int]())
+	is = itertools.Chain(IntRange(0, 5), itertools.Empty[int]())
 	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
 
 	is = itertools.Chain(IntRange(0, 5), IntRange(5, 10))
 	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, slices.Collect(is))
 }
 
+func TestItertools_Chain2(t *testing.T) {
+	kvs := itertools.Chain2(
+		itertools.FromMap(map[string]int{"a": 0, "b": 1}),
+		Empty2[string, int](),
+		itertools.FromMap(map[string]int{"c": 2}),
+	)
+	assert.Equal(t, map[string]int{"a": 0, "b": 1, "c": 2}, maps.Collect(kvs))
+
+	var keys []string
+	for k := range itertools.Chain2(itertools.ZipShortest(itertools.FromSlice([]string{"a", "b"}), IntRange(0, 2)), itertools.FromMap(map[string]int{"c": 2})) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+
+	kvs = itertools.Chain2[string, int]()
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+}
+
 func TestItertools_WithFunc(t *testing.T) {
 	is := itertools.WithFunc(func() int { return 1 })
 	assert.Equal(t, []int{1, 1, 1, 1, 1}, slices.Collect(itertools.Take(is, 5)))
@@ -173,6 +630,31 @@ func TestItertools_WithFunc(t *testing.T) {
 	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(itertools.Take(is, 5)))
 }
 
+func TestItertools_WithFuncErr(t *testing.T) {
+	cursor := func(rows []string) func() (int, error, bool) {
+		return func() (int, error, bool) {
+			if len(rows) == 0 {
+				return 0, nil, false
+			}
+			v, err := strconv.Atoi(rows[0])
+			rows = rows[1:]
+			return v, err, true
+		}
+	}
+
+	is, err := itertools.CollectErr(itertools.WithFuncErr(cursor([]string{"0", "1", "2"})))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, is)
+
+	is, err = itertools.CollectErr(itertools.WithFuncErr(cursor([]string{"0", "a", "2"})))
+	require.Error(t, err)
+	assert.Equal(t, []int{0}, is)
+
+	is, err = itertools.CollectErr(itertools.WithFuncErr(cursor(nil)))
+	require.NoError(t, err)
+	assert.Equal(t, []int(nil), is)
+}
+
 func TestItertools_Repeat(t *testing.T) {
 	ss := itertools.Repeat("a")
 	assert.Equal(t, []string{"a", "a", "a", "a", "a"}, slices.Collect(itertools.Take(ss, 5)))
@@ -184,26 +666,164 @@ func TestItertools_RepeatN(t *testing.T) {
 
 	ss = itertools.RepeatN("a", 0)
 	assert.Equal(t, []string(nil), slices.Collect(ss))
+
+	ss = itertools.RepeatN("a", -1)
+	assert.Equal(t, []string(nil), slices.Collect(ss))
 }
 
 func TestItertools_Cycle(t *testing.T) {
 	is := itertools.Cycle(IntRange(0, 2))
 	assert.Equal(t, []int{0, 1, 0, 1, 0}, slices.Collect(itertools.Take(is, 5)))
 
-	is = itertools.Cycle(Empty[int]())
+	is = itertools.Cycle(itertools.Empty[int]())
 	assert.Equal(t, []int(nil), slices.Collect(itertools.Take(is, 5)))
 }
 
+func TestItertools_CycleN(t *testing.T) {
+	is := itertools.CycleN(IntRange(0, 3), 2)
+	assert.Equal(t, []int{0, 1, 2, 0, 1, 2}, slices.Collect(is))
+
+	is = itertools.CycleN(IntRange(0, 3), 1)
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+
+	is = itertools.CycleN(IntRange(0, 3), uint(3))
+	assert.Equal(t, []int{0, 1, 2, 0}, slices.Collect(itertools.Take(is, 4)))
+
+	is = itertools.CycleN(IntRange(0, 3), 0)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.CycleN(IntRange(0, 3), -1)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.CycleN(itertools.Empty[int](), 5)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_Cached(t *testing.T) {
+	calls := 0
+	is := itertools.Cached(itertools.Map(itertools.FromChannel(itertools.ToChannel(IntRange(0, 5), 0)), func(v int) int {
+		calls++
+		return v
+	}))
+	assert.Equal(t, []int{0, 1}, slices.Collect(itertools.Take(is, 2)))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+	assert.Equal(t, 5, calls)
+
+	is = itertools.Cached(itertools.Empty[int]())
+	assert.Equal(t, []int(nil), slices.Collect(is))
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
 func TestItertools_Flatten(t *testing.T) {
 	is := itertools.Flatten(itertools.Map(IntRange(0, 3), func(v int) iter.Seq[int] {
 		return itertools.RepeatN(v, 2)
 	}))
 	assert.Equal(t, []int{0, 0, 1, 1, 2, 2}, slices.Collect(is))
 
-	is = itertools.Flatten(Empty[iter.Seq[int]]())
+	is = itertools.Flatten(itertools.Empty[iter.Seq[int]]())
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_FlattenInterleave(t *testing.T) {
+	seqs := itertools.Of(itertools.Of(1, 2, 3, 4), itertools.Of(10), itertools.Empty[int](), itertools.Of(100, 200))
+	assert.Equal(t, []int{1, 10, 100, 2, 200, 3, 4}, slices.Collect(itertools.FlattenInterleave(seqs)))
+
+	infinite := itertools.Of(itertools.Repeat(0), itertools.Repeat(1))
+	assert.Equal(t, []int{0, 1, 0, 1, 0}, slices.Collect(itertools.Take(itertools.FlattenInterleave(infinite), 5)))
+
+	is := itertools.FlattenInterleave(itertools.Empty[iter.Seq[int]]())
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_FlattenValues(t *testing.T) {
+	var keys []bool
+	var values []int
+	groups := itertools.GroupAdjacent(itertools.FromSlice([]int{-2, -1, 0, 1, -1}), func(i int) bool { return i < 0 })
+	for k, v := range itertools.FlattenValues(groups) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	assert.Equal(t, []bool{true, true, false, false, true}, keys)
+	assert.Equal(t, []int{-2, -1, 0, 1, -1}, values)
+
+	kvs := itertools.FlattenValues(itertools.FromMap(map[string]iter.Seq[int]{"a": itertools.Empty[int](), "b": itertools.RepeatN(1, 1)}))
+	assert.Equal(t, map[string]int{"b": 1}, maps.Collect(kvs))
+
+	kvs = itertools.FlattenValues(Empty2[string, iter.Seq[int]]())
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+}
+
+func TestItertools_FlatMap2(t *testing.T) {
+	headers := itertools.ZipShortest(itertools.Of("Accept", "Host"), itertools.Of("text/html, text/plain", "example.com"))
+	split := itertools.FlatMap2(headers, func(k, v string) iter.Seq2[string, string] {
+		return itertools.ZipShortest(itertools.Repeat(k), itertools.FromSlice(strings.Split(v, ", ")))
+	})
+	keys, values := itertools.CollectKeysValues(split)
+	assert.Equal(t, []string{"Accept", "Accept", "Host"}, keys)
+	assert.Equal(t, []string{"text/html", "text/plain", "example.com"}, values)
+
+	keys, _ = itertools.CollectKeysValues(itertools.Take2(split, 1))
+	assert.Equal(t, []string{"Accept"}, keys)
+
+	kvs := itertools.FlatMap2(Empty2[string, string](), func(k, v string) iter.Seq2[string, int] {
+		return itertools.ZipShortest(itertools.Repeat(k), IntRange(0, 5))
+	})
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+}
+
+func TestItertools_Flatten2(t *testing.T) {
+	nested := func(v int) iter.Seq[iter.Seq[int]] {
+		return itertools.RepeatN(itertools.FromSlice([]int{v, v}), 2)
+	}
+
+	is := itertools.Flatten2(itertools.Map(itertools.FromSlice([]int{0, 1}), nested))
+	assert.Equal(t, []int{0, 0, 0, 0, 1, 1, 1, 1}, slices.Collect(is))
+
+	is = itertools.Flatten2(itertools.Empty[iter.Seq[iter.Seq[int]]]())
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_Flatten3(t *testing.T) {
+	nested := func(v int) iter.Seq[iter.Seq[iter.Seq[int]]] {
+		inner := itertools.FromSlice([]int{v, -v})
+		return itertools.RepeatN(itertools.FromSlice([]iter.Seq[int]{inner, inner}), 2)
+	}
+
+	is := itertools.Flatten3(itertools.Map(itertools.FromSlice([]int{1, 2}), nested))
+	assert.Equal(t, []int{1, -1, 1, -1, 1, -1, 1, -1, 2, -2, 2, -2, 2, -2, 2, -2}, slices.Collect(is))
+	assert.Equal(t, []int{1, -1, 1}, slices.Collect(itertools.Take(is, 3)))
+
+	is = itertools.Flatten3(itertools.Empty[iter.Seq[iter.Seq[iter.Seq[int]]]]())
 	assert.Equal(t, []int(nil), slices.Collect(is))
 }
 
+func TestItertools_FlattenErr(t *testing.T) {
+	shards := func(shards ...[]string) iter.Seq[iter.Seq2[int, error]] {
+		return itertools.Map(itertools.FromSlice(shards), func(shard []string) iter.Seq2[int, error] {
+			return itertools.TryMap(itertools.FromSlice(shard), strconv.Atoi)
+		})
+	}
+
+	is, err := itertools.CollectErr(itertools.FlattenErr(shards([]string{"0", "1"}, []string{}, []string{"2"})))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, is)
+
+	calls := 0
+	for _, err := range itertools.FlattenErr(shards([]string{"0", "a", "1"}, []string{"2"})) {
+		calls++
+		if calls == 2 {
+			require.Error(t, err)
+		}
+	}
+	assert.Equal(t, 2, calls)
+
+	is, err = itertools.CollectErr(itertools.FlattenErr(itertools.Empty[iter.Seq2[int, error]]()))
+	require.NoError(t, err)
+	assert.Equal(t, []int(nil), is)
+}
+
 func TestItertools_All(t *testing.T) {
 	a := itertools.All(IntRange(0, 3), func(v int) bool { return v >= 0 })
 	assert.Equal(t, true, a)
@@ -246,7 +866,7 @@ func TestItertools_Min(t *testing.T) {
 	assert.Equal(t, true, ok)
 	assert.Equal(t, -1, a)
 
-	_, ok = itertools.Min(Empty[int]())
+	_, ok = itertools.Min(itertools.Empty[int]())
 	assert.Equal(t, false, ok)
 }
 
@@ -255,7 +875,7 @@ func TestItertools_MinFunc(t *testing.T) {
 	assert.Equal(t, true, ok)
 	assert.Equal(t, "abc", a)
 
-	_, ok = itertools.MinFunc(Empty[string](), strings.Compare)
+	_, ok = itertools.MinFunc(itertools.Empty[string](), strings.Compare)
 	assert.Equal(t, false, ok)
 }
 
@@ -268,7 +888,7 @@ func TestItertools_Max(t *testing.T) {
 	assert.Equal(t, true, ok)
 	assert.Equal(t, 5, a)
 
-	_, ok = itertools.Max(Empty[int]())
+	_, ok = itertools.Max(itertools.Empty[int]())
 	assert.Equal(t, false, ok)
 }
 
@@ -277,7 +897,25 @@ func TestItertools_MaxFunc(t *testing.T) {
 	assert.Equal(t, true, ok)
 	assert.Equal(t, "ghi", a)
 
-	_, ok = itertools.MaxFunc(Empty[string](), strings.Compare)
+	_, ok = itertools.MaxFunc(itertools.Empty[string](), strings.Compare)
+	assert.Equal(t, false, ok)
+}
+
+func TestItertools_MinBy(t *testing.T) {
+	a, ok := itertools.MinBy(itertools.Of("ccc", "a", "bb", "d"), func(s string) int { return len(s) })
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "a", a)
+
+	_, ok = itertools.MinBy(itertools.Empty[string](), func(s string) int { return len(s) })
+	assert.Equal(t, false, ok)
+}
+
+func TestItertools_MaxBy(t *testing.T) {
+	a, ok := itertools.MaxBy(itertools.Of("a", "ccc", "bb", "ddd"), func(s string) int { return len(s) })
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "ccc", a)
+
+	_, ok = itertools.MaxBy(itertools.Empty[string](), func(s string) int { return len(s) })
 	assert.Equal(t, false, ok)
 }
 
@@ -327,20 +965,51 @@ func TestItertools_InterleaveLongest(t *testing.T) {
 	assert.Equal(t, []string{"abc", "def", "ghi", "jkl"}, slices.Collect(ss))
 }
 
-func TestItertools_ZipShortest(t *testing.T) {
-	ss := itertools.ZipShortest(
-		itertools.FromSlice([]string{"abc", "ghi"}),
-		itertools.FromSlice([]string{"def", "jkl"}),
+func TestItertools_InterleaveBy(t *testing.T) {
+	ss := itertools.InterleaveBy(
+		itertools.Cycle(itertools.FromSlice([]int{0, 0, 1})),
+		itertools.FromSlice([]string{"a", "b", "c", "d", "e"}),
+		itertools.FromSlice([]string{"1", "2", "3"}),
 	)
-	assert.Equal(t, map[string]string{"abc": "def", "ghi": "jkl"}, maps.Collect(ss))
+	assert.Equal(t, []string{"a", "b", "1", "c", "d", "2", "e"}, slices.Collect(ss))
 
-	ss = itertools.ZipShortest(
-		itertools.FromSlice([]string{"abc", "ghi"}),
-		itertools.FromSlice([]string{"def"}),
+	ss = itertools.InterleaveBy(
+		itertools.FromSlice([]int{1, 1, 0}),
+		itertools.FromSlice([]string{"a", "b"}),
+		itertools.FromSlice([]string{"1", "2", "3"}),
 	)
-	assert.Equal(t, map[string]string{"abc": "def"}, maps.Collect(ss))
+	assert.Equal(t, []string{"1", "2", "a"}, slices.Collect(ss))
 
-	ss = itertools.ZipShortest(
+	ss = itertools.InterleaveBy(
+		itertools.Repeat(0),
+		itertools.Repeat("a"),
+		itertools.Empty[string](),
+	)
+	assert.Equal(t, []string{"a", "a", "a"}, slices.Collect(itertools.Take(ss, 3)))
+
+	ss = itertools.InterleaveBy(itertools.Empty[int](), itertools.Repeat("a"))
+	assert.Equal(t, []string(nil), slices.Collect(ss))
+
+	assert.Panics(t, func() {
+		for range itertools.InterleaveBy(itertools.FromSlice([]int{1}), itertools.Repeat("a")) {
+		}
+	})
+}
+
+func TestItertools_ZipShortest(t *testing.T) {
+	ss := itertools.ZipShortest(
+		itertools.FromSlice([]string{"abc", "ghi"}),
+		itertools.FromSlice([]string{"def", "jkl"}),
+	)
+	assert.Equal(t, map[string]string{"abc": "def", "ghi": "jkl"}, maps.Collect(ss))
+
+	ss = itertools.ZipShortest(
+		itertools.FromSlice([]string{"abc", "ghi"}),
+		itertools.FromSlice([]string{"def"}),
+	)
+	assert.Equal(t, map[string]string{"abc": "def"}, maps.Collect(ss))
+
+	ss = itertools.ZipShortest(
 		itertools.FromSlice([]string{"abc"}),
 		itertools.FromSlice([]string{"def", "jkl"}),
 	)
@@ -353,6 +1022,64 @@ func TestItertools_ZipShortest(t *testing.T) {
 	assert.Equal(t, map[string]string{}, maps.Collect(ss))
 }
 
+func TestItertools_Pair(t *testing.T) {
+	p := itertools.Pair[string, int]{First: "a", Second: 1}
+	assert.Equal(t, itertools.Pair[int, string]{First: 1, Second: "a"}, p.Swap())
+	assert.Equal(t, p, p.Swap().Swap())
+}
+
+func TestItertools_Pairs(t *testing.T) {
+	ps := itertools.Pairs(itertools.FromSlice([]string{"a", "b", "c"}), IntRange(0, 2))
+	assert.Equal(t, []itertools.Pair[string, int]{{"a", 0}, {"b", 1}}, slices.Collect(ps))
+
+	ps = itertools.Pairs(itertools.Empty[string](), IntRange(0, 2))
+	assert.Equal(t, []itertools.Pair[string, int](nil), slices.Collect(ps))
+}
+
+func TestItertools_ToPairs(t *testing.T) {
+	ps := itertools.ToPairs(itertools.ZipShortest(itertools.Of("a", "b"), IntRange(0, 2)))
+	assert.Equal(t, []itertools.Pair[string, int]{{"a", 0}, {"b", 1}}, slices.Collect(ps))
+
+	ps = itertools.ToPairs(Empty2[string, int]())
+	assert.Equal(t, []itertools.Pair[string, int](nil), slices.Collect(ps))
+}
+
+func TestItertools_FromPairs(t *testing.T) {
+	kvs := itertools.FromPairs(itertools.Of(itertools.Pair[string, int]{"a", 0}, itertools.Pair[string, int]{"b", 1}))
+	assert.Equal(t, map[string]int{"a": 0, "b": 1}, maps.Collect(kvs))
+
+	sorted := itertools.FromPairs(itertools.SortBy(itertools.ToPairs(itertools.FromMap(map[string]int{"a": 2, "b": 0, "c": 1})), func(p itertools.Pair[string, int]) int {
+		return p.Second
+	}))
+	var keys []string
+	for k := range sorted {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"b", "c", "a"}, keys)
+
+	kvs = itertools.FromPairs(itertools.Empty[itertools.Pair[string, int]]())
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+}
+
+func TestItertools_Zip3(t *testing.T) {
+	ts := itertools.Zip3(
+		itertools.FromSlice([]string{"a", "b", "c"}),
+		IntRange(0, 5),
+		itertools.FromSlice([]bool{true, false}),
+	)
+	assert.Equal(t, []itertools.Triple[string, int, bool]{{"a", 0, true}, {"b", 1, false}}, slices.Collect(ts))
+
+	ts = itertools.Zip3(
+		itertools.FromSlice([]string{"a", "b", "c"}),
+		IntRange(0, 5),
+		itertools.Repeat(true),
+	)
+	assert.Equal(t, []itertools.Triple[string, int, bool]{{"a", 0, true}, {"b", 1, true}, {"c", 2, true}}, slices.Collect(ts))
+
+	ts = itertools.Zip3(itertools.Empty[string](), IntRange(0, 5), itertools.Repeat(true))
+	assert.Equal(t, []itertools.Triple[string, int, bool](nil), slices.Collect(ts))
+}
+
 func TestItertools_ChunkBy(t *testing.T) {
 	iss := itertools.ChunkBy(IntRange(-2, 2), func(i int) bool {
 		return i < 0
@@ -362,7 +1089,7 @@ func TestItertools_ChunkBy(t *testing.T) {
 	require.Equal(t, []int{-2, -1}, collected[0])
 	require.Equal(t, []int{0, 1}, collected[1])
 
-	iss = itertools.ChunkBy(Empty[int](), func(i int) bool {
+	iss = itertools.ChunkBy(itertools.Empty[int](), func(i int) bool {
 		return i < 0
 	})
 	collected = slices.Collect(itertools.Map(iss, slices.Collect))
@@ -401,6 +1128,101 @@ func TestItertools_ChunkBy(t *testing.T) {
 	}
 }
 
+func TestItertools_GroupAdjacent(t *testing.T) {
+	var keys []bool
+	var groups [][]int
+	for k, g := range itertools.GroupAdjacent(itertools.FromSlice([]int{-2, -1, 0, 1, -1}), func(i int) bool { return i < 0 }) {
+		keys = append(keys, k)
+		groups = append(groups, slices.Collect(g))
+	}
+	assert.Equal(t, []bool{true, false, true}, keys)
+	assert.Equal(t, [][]int{{-2, -1}, {0, 1}, {-1}}, groups)
+
+	keys = nil
+	for k := range itertools.GroupAdjacent(IntRange(0, 10), func(i int) int { return i / 3 }) {
+		keys = append(keys, k == 0)
+		if k == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []bool{true, false}, keys)
+
+	kgs := itertools.GroupAdjacent(itertools.Empty[int](), func(i int) bool { return i < 0 })
+	assert.Equal(t, 0, len(maps.Collect(kgs)))
+}
+
+func TestItertools_ChunkByChanges(t *testing.T) {
+	iss := itertools.ChunkByChanges(itertools.Of(1, 1, 2, 3, 3, 3, 1))
+	assert.Equal(t, [][]int{{1, 1}, {2}, {3, 3, 3}, {1}}, slices.Collect(itertools.Map(iss, slices.Collect)))
+
+	iss = itertools.ChunkByChanges(itertools.RepeatN(1, 3))
+	assert.Equal(t, [][]int{{1, 1, 1}}, slices.Collect(itertools.Map(iss, slices.Collect)))
+
+	iss = itertools.ChunkByChanges(itertools.Empty[int]())
+	assert.Equal(t, [][]int(nil), slices.Collect(itertools.Map(iss, slices.Collect)))
+}
+
+func TestItertools_ChunkByIndexed(t *testing.T) {
+	var indices []int
+	var groups [][]int
+	for i, g := range itertools.ChunkByIndexed(itertools.Of(-2, -1, 0, 1, -1), func(i int) bool { return i < 0 }) {
+		indices = append(indices, i)
+		groups = append(groups, slices.Collect(g))
+	}
+	assert.Equal(t, []int{0, 1, 2}, indices)
+	assert.Equal(t, [][]int{{-2, -1}, {0, 1}, {-1}}, groups)
+
+	igs := itertools.ChunkByIndexed(IntRange(0, 10), func(i int) int { return i / 2 })
+	assert.Equal(t, 2, len(maps.Collect(itertools.Take2(igs, 2))))
+
+	igs = itertools.ChunkByIndexed(itertools.Empty[int](), func(i int) bool { return i < 0 })
+	assert.Equal(t, 0, len(maps.Collect(igs)))
+}
+
+func TestItertools_ChunkCounts(t *testing.T) {
+	var keys []bool
+	var counts []int
+	for k, c := range itertools.ChunkCounts(itertools.Of(-2, -1, 0, 1, 2, -1), func(i int) bool { return i < 0 }) {
+		keys = append(keys, k)
+		counts = append(counts, c)
+	}
+	assert.Equal(t, []bool{true, false, true}, keys)
+	assert.Equal(t, []int{2, 3, 1}, counts)
+
+	kcs := itertools.ChunkCounts(itertools.Of("a", "b", "b"), func(s string) string { return s })
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, maps.Collect(kcs))
+
+	kcs = itertools.ChunkCounts(itertools.Of("a", "b", "b"), func(s string) string { return s })
+	assert.Equal(t, map[string]int{"a": 1}, maps.Collect(itertools.Take2(kcs, 1)))
+
+	kcs = itertools.ChunkCounts(itertools.Empty[string](), func(s string) string { return s })
+	assert.Equal(t, map[string]int{}, maps.Collect(kcs))
+}
+
+func TestItertools_ChunkWhile(t *testing.T) {
+	iss := itertools.ChunkWhile(itertools.FromSlice([]int{1, 2, 4, 9, 10, 11, 12, 15, 16, 19, 20, 21}), func(prev, cur int) bool {
+		return cur-prev <= 1
+	})
+	collected := slices.Collect(itertools.Map(iss, slices.Collect))
+	assert.Equal(t, [][]int{{1, 2}, {4}, {9, 10, 11, 12}, {15, 16}, {19, 20, 21}}, collected)
+
+	iss = itertools.ChunkWhile(IntRange(0, 5), func(prev, cur int) bool { return false })
+	collected = slices.Collect(itertools.Map(iss, slices.Collect))
+	assert.Equal(t, [][]int{{0}, {1}, {2}, {3}, {4}}, collected)
+
+	iss = itertools.ChunkWhile(IntRange(0, 5), func(prev, cur int) bool { return true })
+	collected = slices.Collect(itertools.Map(iss, slices.Collect))
+	assert.Equal(t, [][]int{{0, 1, 2, 3, 4}}, collected)
+
+	iss = itertools.ChunkWhile(IntRange(0, 5), func(prev, cur int) bool { return false })
+	collected = slices.Collect(itertools.Map(itertools.Take(iss, 2), slices.Collect))
+	assert.Equal(t, [][]int{{0}, {1}}, collected)
+
+	iss = itertools.ChunkWhile(itertools.Empty[int](), func(prev, cur int) bool { return true })
+	collected = slices.Collect(itertools.Map(iss, slices.Collect))
+	assert.Equal(t, [][]int(nil), collected)
+}
+
 func TestItertools_Chunks(t *testing.T) {
 	iss := itertools.Chunks(IntRange(0, 10), 2)
 	collected := slices.Collect(itertools.Map(iss, slices.Collect))
@@ -408,6 +1230,57 @@ func TestItertools_Chunks(t *testing.T) {
 	for i := range 5 {
 		require.Equal(t, []int{i * 2, i*2 + 1}, collected[i])
 	}
+
+	iss = itertools.Chunks(IntRange(0, 5), int8(3))
+	collected = slices.Collect(itertools.Map(iss, slices.Collect))
+	require.Equal(t, [][]int{{0, 1, 2}, {3, 4}}, collected)
+
+	iss = itertools.Chunks(itertools.Of(1, 2, 3), 2)
+	require.Equal(t, [][]int{{1, 2}, {3}}, slices.Collect(itertools.Map(iss, slices.Collect)))
+	require.Equal(t, [][]int{{1, 2}, {3}}, slices.Collect(itertools.Map(iss, slices.Collect)))
+
+	assert.PanicsWithValue(t, "itertools: Chunks size must be > 0", func() { itertools.Chunks(IntRange(0, 5), 0) })
+	assert.PanicsWithValue(t, "itertools: Chunks size must be > 0", func() { itertools.Chunks(IntRange(0, 5), -1) })
+}
+
+func TestItertools_ChunksPadded(t *testing.T) {
+	chunks := itertools.ChunksPadded(IntRange(0, 5), 2, -1)
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4, -1}}, slices.Collect(chunks))
+
+	chunks = itertools.ChunksPadded(IntRange(0, 4), uint(2), -1)
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}}, slices.Collect(chunks))
+
+	chunks = itertools.ChunksPadded(IntRange(0, 1), 4, 0)
+	assert.Equal(t, [][]int{{0, 0, 0, 0}}, slices.Collect(chunks))
+
+	chunks = itertools.ChunksPadded(itertools.Repeat(1), 3, 0)
+	assert.Equal(t, [][]int{{1, 1, 1}, {1, 1, 1}}, slices.Collect(itertools.Take(chunks, 2)))
+
+	chunks = itertools.ChunksPadded(itertools.Empty[int](), 3, 0)
+	assert.Equal(t, [][]int(nil), slices.Collect(chunks))
+
+	assert.PanicsWithValue(t, "itertools: ChunksPadded size must be > 0", func() { itertools.ChunksPadded(IntRange(0, 5), 0, 0) })
+	assert.PanicsWithValue(t, "itertools: ChunksPadded size must be > 0", func() { itertools.ChunksPadded(IntRange(0, 5), -1, 0) })
+}
+
+func TestItertools_WindowReduce(t *testing.T) {
+	sum := func(vs []int) int {
+		return itertools.Reduce(itertools.FromSlice(vs), func(a, b int) int { return a + b }, 0)
+	}
+
+	is := itertools.WindowReduce(IntRange(0, 5), 3, sum)
+	assert.Equal(t, []int{0 + 1 + 2, 1 + 2 + 3, 2 + 3 + 4}, slices.Collect(is))
+
+	iss := itertools.WindowReduce(IntRange(0, 5), 2, slices.Clone[[]int])
+	assert.Equal(t, [][]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}}, slices.Collect(iss))
+
+	is = itertools.WindowReduce(IntRange(0, 5), 1, sum)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	is = itertools.WindowReduce(IntRange(0, 2), 3, sum)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	assert.Panics(t, func() { itertools.WindowReduce(IntRange(0, 5), 0, sum) })
 }
 
 func TestItertools_ReverseSlice(t *testing.T) {
@@ -424,8 +1297,1181 @@ func TestItertools_ReverseSlice(t *testing.T) {
 func TestItertools_IsSorted(t *testing.T) {
 	require.True(t, itertools.IsSorted(itertools.FromSlice([]int{0, 1, 2, 3, 4})))
 	require.False(t, itertools.IsSorted(itertools.FromSlice([]int{0, 1, 2, 5, 4})))
-	require.True(t, itertools.IsSorted(Empty[int]()))
+	require.True(t, itertools.IsSorted(itertools.Empty[int]()))
 	require.True(t, itertools.IsSorted(itertools.FromSlice([]int{1})))
 	require.False(t, itertools.IsSorted(itertools.FromSlice([]int{1, 0})))
 	require.True(t, itertools.IsSorted(itertools.RepeatN(1, 5)))
 }
+
+func TestItertools_IsSortedByKey(t *testing.T) {
+	require.True(t, itertools.IsSortedByKey(itertools.ZipShortest(itertools.FromSlice([]int{0, 1, 1, 3}), itertools.FromSlice([]string{"d", "c", "b", "a"}))))
+	require.False(t, itertools.IsSortedByKey(itertools.ZipShortest(itertools.FromSlice([]int{0, 2, 1}), itertools.FromSlice([]string{"a", "b", "c"}))))
+	require.True(t, itertools.IsSortedByKey(Empty2[int, string]()))
+	require.True(t, itertools.IsSortedByKey(itertools.ZipShortest(itertools.FromSlice([]int{1}), itertools.Repeat("a"))))
+	require.False(t, itertools.IsSortedByKey(itertools.ZipShortest(itertools.FromSlice([]string{"b", "a"}), IntRange(0, 2))))
+}
+
+func TestItertools_UniqueKeys(t *testing.T) {
+	kvs := itertools.UniqueKeys(itertools.ZipShortest(
+		itertools.FromSlice([]string{"a", "b", "a", "c", "b"}),
+		itertools.FromSlice([]int{0, 1, 2, 3, 4}),
+	))
+	assert.Equal(t, map[string]int{"a": 0, "b": 1, "c": 3}, maps.Collect(kvs))
+
+	var keys []string
+	for k := range kvs {
+		keys = append(keys, k)
+		if k == "b" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	kvs = itertools.UniqueKeys(Empty2[string, int]())
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+}
+
+func TestItertools_Stats(t *testing.T) {
+	mean, variance, count := itertools.Stats(itertools.FromSlice([]int{2, 4, 4, 4, 5, 5, 7, 9}))
+	assert.Equal(t, 8, count)
+	assert.InDelta(t, 5.0, mean, 1e-9)
+	assert.InDelta(t, 4.0, variance, 1e-9)
+
+	mean, variance, count = itertools.Stats(itertools.FromSlice([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}))
+	assert.Equal(t, 4, count)
+	assert.InDelta(t, 1e9+10, mean, 1e-6)
+	assert.InDelta(t, 22.5, variance, 1e-6)
+
+	mean, variance, count = itertools.Stats(itertools.RepeatN(uint8(3), 1))
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 3.0, mean)
+	assert.Equal(t, 0.0, variance)
+
+	_, _, count = itertools.Stats(itertools.Empty[float32]())
+	assert.Equal(t, 0, count)
+}
+
+func TestItertools_Counted(t *testing.T) {
+	n := 0
+	is := itertools.Counted(IntRange(0, 5), &n)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+	assert.Equal(t, 5, n)
+
+	n = 0
+	assert.True(t, itertools.Any(itertools.Counted(itertools.Repeat(1), &n), func(v int) bool { return v == 1 }))
+	assert.Equal(t, 1, n)
+
+	n = 0
+	assert.Equal(t, []int(nil), slices.Collect(itertools.Counted(itertools.Empty[int](), &n)))
+	assert.Equal(t, 0, n)
+}
+
+func TestItertools_Sample(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	is := itertools.Sample(IntRange(0, 100), 5, rng)
+	require.Equal(t, 5, len(is))
+	for _, i := range is {
+		assert.True(t, 0 <= i && i < 100)
+	}
+	assert.Equal(t, is, itertools.Sample(IntRange(0, 100), 5, rand.New(rand.NewPCG(1, 2))))
+
+	counts := make([]int, 4)
+	for range 4000 {
+		for _, i := range itertools.Sample(IntRange(0, 4), 1, rng) {
+			counts[i]++
+		}
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 1000, c, 150)
+	}
+
+	is = itertools.Sample(IntRange(0, 3), 5, rng)
+	assert.Equal(t, []int{0, 1, 2}, is)
+
+	is = itertools.Sample(IntRange(0, 3), 0, rng)
+	assert.Equal(t, []int(nil), is)
+
+	is = itertools.Sample(itertools.Empty[int](), 5, rng)
+	assert.Equal(t, []int(nil), is)
+}
+
+func TestItertools_Shuffle(t *testing.T) {
+	is := slices.Collect(itertools.Shuffle(IntRange(0, 100), rand.New(rand.NewPCG(1, 2))))
+	assert.ElementsMatch(t, slices.Collect(IntRange(0, 100)), is)
+	assert.NotEqual(t, slices.Collect(IntRange(0, 100)), is)
+	assert.Equal(t, is, slices.Collect(itertools.Shuffle(IntRange(0, 100), rand.New(rand.NewPCG(1, 2)))))
+
+	is = slices.Collect(itertools.Shuffle(IntRange(0, 1), rand.New(rand.NewPCG(1, 2))))
+	assert.Equal(t, []int{0}, is)
+
+	is = slices.Collect(itertools.Shuffle(itertools.Empty[int](), rand.New(rand.NewPCG(1, 2))))
+	assert.Equal(t, []int(nil), is)
+}
+
+func TestItertools_Histogram(t *testing.T) {
+	h := itertools.Histogram(IntRange(0, 10), 0, 10, 5)
+	assert.Equal(t, []int{2, 2, 2, 2, 2}, h)
+
+	h = itertools.Histogram(itertools.FromSlice([]float64{-5, 0, 0.49, 0.5, 1, 7}), 0, 1, 2)
+	assert.Equal(t, []int{3, 3}, h)
+
+	h = itertools.Histogram(itertools.Of(1e300, math.Inf(1), 0.5, math.Inf(-1), -1e300, math.NaN()), 0.0, 1.0, 4)
+	assert.Equal(t, []int{2, 0, 1, 2}, h)
+
+	h = itertools.Histogram(itertools.Empty[int](), 0, 10, 3)
+	assert.Equal(t, []int{0, 0, 0}, h)
+
+	assert.Panics(t, func() { itertools.Histogram(itertools.Empty[int](), 0, 10, 0) })
+	assert.Panics(t, func() { itertools.Histogram(itertools.Empty[int](), 10, 10, 1) })
+}
+
+func TestItertools_MostCommon(t *testing.T) {
+	words := strings.Fields("b a c a b d a e c b")
+
+	var vs []string
+	var counts []int
+	for v, c := range itertools.MostCommon(itertools.FromSlice(words), 3) {
+		vs = append(vs, v)
+		counts = append(counts, c)
+	}
+	assert.Equal(t, []string{"b", "a", "c"}, vs)
+	assert.Equal(t, []int{3, 3, 2}, counts)
+
+	kvs := itertools.MostCommon(itertools.FromSlice(words), 10)
+	assert.Equal(t, map[string]int{"a": 3, "b": 3, "c": 2, "d": 1, "e": 1}, maps.Collect(kvs))
+
+	kvs = itertools.MostCommon(itertools.FromSlice(words), 0)
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+
+	kvs = itertools.MostCommon(itertools.FromSlice(words), -1)
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+
+	kvs = itertools.MostCommon(itertools.Empty[string](), 3)
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+}
+
+func TestItertools_Iterator(t *testing.T) {
+	is := itertools.From(IntRange(0, 100)).
+		Filter(func(v int) bool { return v%2 == 0 }).
+		Drop(1).
+		Take(4).
+		Map(func(v int) int { return v * 10 }).
+		Collect()
+	assert.Equal(t, []int{20, 40, 60, 80}, is)
+
+	is = itertools.From(IntRange(0, 10)).
+		DropWhile(func(v int) bool { return v < 3 }).
+		TakeWhile(func(v int) bool { return v < 6 }).
+		Collect()
+	assert.Equal(t, []int{3, 4, 5}, is)
+
+	var collected []int
+	itertools.From(IntRange(0, 3)).ForEach(func(v int) { collected = append(collected, v) })
+	assert.Equal(t, []int{0, 1, 2}, collected)
+
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(itertools.From(IntRange(0, 3)).Seq()))
+	assert.Equal(t, []int(nil), itertools.From(itertools.Empty[int]()).Take(3).Collect())
+}
+
+func TestItertools_MapPipe(t *testing.T) {
+	ss := itertools.MapPipe(itertools.From(IntRange(0, 10)).Filter(func(v int) bool { return v%3 == 0 }), strconv.Itoa).
+		Take(3).
+		Collect()
+	assert.Equal(t, []string{"0", "3", "6"}, ss)
+
+	ss = itertools.MapPipe(itertools.From(itertools.Empty[int]()), strconv.Itoa).Collect()
+	assert.Equal(t, []string(nil), ss)
+}
+
+func TestItertools_Pipe(t *testing.T) {
+	evens := func(seq iter.Seq[int]) iter.Seq[int] {
+		return itertools.Filter(seq, func(v int) bool { return v%2 == 0 })
+	}
+	firstThree := func(seq iter.Seq[int]) iter.Seq[int] {
+		return itertools.Take(seq, 3)
+	}
+
+	is := itertools.Pipe(IntRange(0, 10), evens, firstThree)
+	assert.Equal(t, []int{0, 2, 4}, slices.Collect(is))
+
+	is = itertools.Pipe(IntRange(0, 10), firstThree, evens)
+	assert.Equal(t, []int{0, 2}, slices.Collect(is))
+
+	is = itertools.Pipe(itertools.WithFunc(func() int { return 2 }), evens, firstThree)
+	assert.Equal(t, []int{2, 2, 2}, slices.Collect(is))
+
+	is = itertools.Pipe(IntRange(0, 3))
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+}
+
+func TestItertools_Join(t *testing.T) {
+	s := itertools.Join(itertools.Map(IntRange(0, 5), strconv.Itoa), ", ")
+	assert.Equal(t, "0, 1, 2, 3, 4", s)
+
+	s = itertools.Join(itertools.FromSlice([]string{"a"}), ", ")
+	assert.Equal(t, "a", s)
+
+	s = itertools.Join(itertools.FromSlice([]string{"", ""}), "-")
+	assert.Equal(t, "-", s)
+
+	s = itertools.Join(itertools.Empty[string](), ", ")
+	assert.Equal(t, "", s)
+}
+
+func TestItertools_FirstN(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2}, itertools.FirstN(IntRange(0, 5), 3))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, itertools.FirstN(IntRange(0, 5), uint(10)))
+	assert.Equal(t, []int(nil), itertools.FirstN(IntRange(0, 5), 0))
+	assert.Equal(t, []int(nil), itertools.FirstN(IntRange(0, 5), -1))
+	assert.Equal(t, []int(nil), itertools.FirstN(itertools.Empty[int](), 3))
+
+	n := 0
+	assert.Equal(t, []int{1, 1}, itertools.FirstN(itertools.Counted(itertools.Repeat(1), &n), 2))
+	assert.Equal(t, 2, n)
+}
+
+func TestItertools_LastN(t *testing.T) {
+	assert.Equal(t, []int{2, 3, 4}, itertools.LastN(IntRange(0, 5), 3))
+	assert.Equal(t, []int{4}, itertools.LastN(IntRange(0, 5), 1))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, itertools.LastN(IntRange(0, 5), uint(10)))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, itertools.LastN(IntRange(0, 5), 5))
+	assert.Equal(t, []int(nil), itertools.LastN(IntRange(0, 5), 0))
+	assert.Equal(t, []int(nil), itertools.LastN(IntRange(0, 5), -1))
+	assert.Equal(t, []int(nil), itertools.LastN(itertools.Empty[int](), 3))
+}
+
+func TestItertools_SortBy(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	people := []person{{"alice", 30}, {"bob", 25}, {"carol", 35}, {"dave", 25}}
+
+	ps := itertools.SortBy(itertools.FromSlice(people), func(p person) int { return p.age })
+	assert.Equal(t, []person{{"bob", 25}, {"dave", 25}, {"alice", 30}, {"carol", 35}}, slices.Collect(ps))
+
+	calls := 0
+	ss := itertools.SortBy(itertools.FromSlice([]string{"ccc", "a", "bb"}), func(s string) int {
+		calls++
+		return len(s)
+	})
+	assert.Equal(t, []string{"a", "bb", "ccc"}, slices.Collect(ss))
+	assert.Equal(t, 3, calls)
+
+	ss = itertools.SortBy(itertools.Empty[string](), func(s string) int { return len(s) })
+	assert.Equal(t, []string(nil), slices.Collect(ss))
+}
+
+func TestItertools_Clamp(t *testing.T) {
+	is := itertools.Clamp(itertools.FromSlice([]int{-5, 0, 3, 7, 12}), 0, 10)
+	assert.Equal(t, []int{0, 0, 3, 7, 10}, slices.Collect(is))
+
+	fs := itertools.Clamp(itertools.FromSlice([]float64{-1.5, 0.5, 1.5}), 0, 1)
+	assert.Equal(t, []float64{0, 0.5, 1}, slices.Collect(fs))
+
+	is = itertools.Clamp(IntRange(0, 5), 2, 2)
+	assert.Equal(t, []int{2, 2, 2, 2, 2}, slices.Collect(is))
+
+	is = itertools.Clamp(itertools.Repeat(100), 0, 10)
+	assert.Equal(t, []int{10, 10}, slices.Collect(itertools.Take(is, 2)))
+
+	is = itertools.Clamp(itertools.Empty[int](), 0, 10)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	assert.Panics(t, func() { itertools.Clamp(itertools.Empty[int](), 10, 0) })
+}
+
+func TestItertools_ScanRight(t *testing.T) {
+	is := itertools.ScanRight(IntRange(1, 5), func(v, acc int) int { return v + acc }, 0)
+	assert.Equal(t, []int{4, 7, 9, 10}, slices.Collect(is))
+
+	is = itertools.ScanRight(itertools.FromSlice([]int{3, 1, 4, 1, 2}), func(v, acc int) int { return max(v, acc) }, 0)
+	assert.Equal(t, []int{2, 2, 4, 4, 4}, slices.Collect(is))
+
+	ss := itertools.ScanRight(itertools.FromSlice([]string{"a", "b", "c"}), func(v string, acc string) string { return v + acc }, "!")
+	assert.Equal(t, []string{"c!", "bc!"}, slices.Collect(itertools.Take(ss, 2)))
+
+	is = itertools.ScanRight(itertools.Empty[int](), func(v, acc int) int { return v + acc }, 0)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_CountDistinct(t *testing.T) {
+	assert.Equal(t, 5, itertools.CountDistinct(IntRange(0, 5)))
+	assert.Equal(t, 3, itertools.CountDistinct(itertools.FromSlice([]string{"a", "b", "a", "c", "b"})))
+	assert.Equal(t, 1, itertools.CountDistinct(itertools.RepeatN(1, 5)))
+	assert.Equal(t, 0, itertools.CountDistinct(itertools.Empty[int]()))
+}
+
+func TestItertools_Coalesce(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+
+	is := itertools.Coalesce(itertools.FromSlice([]int{1, 2, 10, 11, 3, 20}), add, func(prev, cur int) bool {
+		return prev/10 != cur/10
+	})
+	assert.Equal(t, []int{3, 21, 3, 20}, slices.Collect(is))
+
+	is = itertools.Coalesce(IntRange(0, 5), add, func(prev, cur int) bool { return true })
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	is = itertools.Coalesce(IntRange(0, 5), add, func(prev, cur int) bool { return false })
+	assert.Equal(t, []int{0 + 1 + 2 + 3 + 4}, slices.Collect(is))
+
+	is = itertools.Coalesce(IntRange(0, 5), add, func(prev, cur int) bool { return true })
+	assert.Equal(t, []int{0, 1}, slices.Collect(itertools.Take(is, 2)))
+
+	is = itertools.Coalesce(itertools.Empty[int](), add, func(prev, cur int) bool { return true })
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_EMA(t *testing.T) {
+	fs := slices.Collect(itertools.EMA(itertools.FromSlice([]float64{10, 20, 20, 0}), 0.5))
+	assert.InDeltaSlice(t, []float64{10, 15, 17.5, 8.75}, fs, 1e-9)
+
+	fs = slices.Collect(itertools.EMA(itertools.FromSlice([]float64{1, 2, 3}), 1))
+	assert.Equal(t, []float64{1, 2, 3}, fs)
+
+	fs = slices.Collect(itertools.EMA(itertools.Empty[float64](), 0.5))
+	assert.Equal(t, []float64(nil), fs)
+
+	assert.Panics(t, func() { itertools.EMA(itertools.Empty[float64](), 0) })
+	assert.Panics(t, func() { itertools.EMA(itertools.Empty[float64](), 1.5) })
+}
+
+func TestItertools_JoinByKey(t *testing.T) {
+	names := itertools.ZipShortest(itertools.FromSlice([]int{1, 2, 3}), itertools.FromSlice([]string{"alice", "bob", "carol"}))
+	orders := itertools.ZipShortest(itertools.FromSlice([]int{3, 1, 4, 1}), itertools.FromSlice([]float64{9.5, 1.5, 7, 2.5}))
+
+	var keys []int
+	var joined []itertools.Pair[string, float64]
+	for k, p := range itertools.JoinByKey(names, orders) {
+		keys = append(keys, k)
+		joined = append(joined, p)
+	}
+	assert.Equal(t, []int{1, 1, 3}, keys)
+	assert.Equal(t, []itertools.Pair[string, float64]{{"alice", 1.5}, {"alice", 2.5}, {"carol", 9.5}}, joined)
+
+	kps := itertools.JoinByKey(itertools.FromMap(map[int]string{1: "a"}), Empty2[int, float64]())
+	assert.Equal(t, 0, len(maps.Collect(kps)))
+
+	kps = itertools.JoinByKey(Empty2[int, string](), itertools.FromMap(map[int]float64{1: 1}))
+	assert.Equal(t, 0, len(maps.Collect(kps)))
+}
+
+func TestItertools_ExcludeKeys(t *testing.T) {
+	kvs := itertools.ExcludeKeys(itertools.FromMap(map[string]int{"a": 0, "b": 1, "c": 2}), itertools.FromSlice([]string{"b", "d"}))
+	assert.Equal(t, map[string]int{"a": 0, "c": 2}, maps.Collect(kvs))
+
+	kvs = itertools.ExcludeKeys(itertools.FromMap(map[string]int{"a": 0, "b": 1}), itertools.Empty[string]())
+	assert.Equal(t, map[string]int{"a": 0, "b": 1}, maps.Collect(kvs))
+
+	kvs = itertools.ExcludeKeys(itertools.ZipShortest(itertools.Repeat("a"), IntRange(0, 5)), itertools.FromSlice([]string{"b"}))
+	assert.Equal(t, map[string]int{"a": 0}, maps.Collect(itertools.Take2(kvs, 1)))
+
+	kvs = itertools.ExcludeKeys(Empty2[string, int](), itertools.FromSlice([]string{"a"}))
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+}
+
+func TestItertools_Throttle(t *testing.T) {
+	start := time.Now()
+	is := itertools.Throttle(IntRange(0, 4), 10*time.Millisecond)
+	assert.Equal(t, []int{0, 1, 2, 3}, slices.Collect(is))
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	start = time.Now()
+	for range itertools.Throttle(IntRange(0, 4), time.Hour) {
+		break
+	}
+	assert.Less(t, time.Since(start), time.Second)
+
+	is = itertools.Throttle(itertools.Empty[int](), time.Hour)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_ThrottleContext(t *testing.T) {
+	is := itertools.ThrottleContext(context.Background(), IntRange(0, 3), time.Millisecond)
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	is = itertools.ThrottleContext(ctx, IntRange(0, 3), time.Hour)
+	assert.Equal(t, []int{0}, slices.Collect(is))
+	assert.Less(t, time.Since(start), time.Second)
+
+	is = itertools.ThrottleContext(ctx, IntRange(0, 3), 0)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_WithTimeout(t *testing.T) {
+	is := itertools.WithTimeout(IntRange(0, 5), time.Second)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	i := -1
+	is = itertools.WithTimeout(itertools.WithFunc(func() int {
+		i++
+		if i == 3 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		return i
+	}), 20*time.Millisecond)
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+
+	is = itertools.WithTimeout(IntRange(0, 5), time.Second)
+	assert.Equal(t, []int{0, 1}, slices.Collect(itertools.Take(is, 2)))
+
+	is = itertools.WithTimeout(itertools.Empty[int](), time.Second)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_GroupByReduce(t *testing.T) {
+	type event struct {
+		user   string
+		amount int
+	}
+	events := []event{{"alice", 3}, {"bob", 1}, {"alice", 4}, {"carol", 5}, {"bob", 9}}
+
+	totals := itertools.GroupByReduce(itertools.FromSlice(events), func(e event) string { return e.user }, func(acc int, e event) int {
+		return acc + e.amount
+	}, func() int { return 0 })
+	assert.Equal(t, map[string]int{"alice": 7, "bob": 10, "carol": 5}, totals)
+
+	amounts := itertools.GroupByReduce(itertools.FromSlice(events), func(e event) string { return e.user }, func(acc []int, e event) []int {
+		return append(acc, e.amount)
+	}, func() []int { return []int{} })
+	assert.Equal(t, map[string][]int{"alice": {3, 4}, "bob": {1, 9}, "carol": {5}}, amounts)
+
+	totals = itertools.GroupByReduce(itertools.Empty[event](), func(e event) string { return e.user }, func(acc int, e event) int {
+		return acc + e.amount
+	}, func() int { return 0 })
+	assert.Equal(t, map[string]int{}, totals)
+}
+
+func TestItertools_Ngrams(t *testing.T) {
+	words := strings.Fields("the quick brown fox")
+
+	grams := slices.Collect(itertools.Ngrams(itertools.FromSlice(words), 2))
+	assert.Equal(t, [][]string{{"the", "quick"}, {"quick", "brown"}, {"brown", "fox"}}, grams)
+
+	grams = slices.Collect(itertools.Ngrams(itertools.FromSlice(words), 4))
+	assert.Equal(t, [][]string{words}, grams)
+
+	grams = slices.Collect(itertools.Ngrams(itertools.FromSlice(words), 5))
+	assert.Equal(t, [][]string(nil), grams)
+
+	grams = slices.Collect(itertools.Ngrams(itertools.Empty[string](), 1))
+	assert.Equal(t, [][]string(nil), grams)
+
+	assert.PanicsWithValue(t, "itertools: Ngrams n must be > 0", func() { itertools.Ngrams(itertools.Empty[string](), 0) })
+}
+
+func TestItertools_Product(t *testing.T) {
+	tuples := itertools.Product(itertools.Of(1, 2), itertools.Of(3), itertools.Of(4, 5))
+	assert.Equal(t, [][]int{{1, 3, 4}, {1, 3, 5}, {2, 3, 4}, {2, 3, 5}}, slices.Collect(tuples))
+
+	tuples = itertools.Product(itertools.Of(1, 2, 3))
+	assert.Equal(t, [][]int{{1}, {2}, {3}}, slices.Collect(tuples))
+
+	tuples = itertools.Product(itertools.Repeat(0), itertools.Of(1, 2))
+	assert.Equal(t, [][]int{{0, 1}, {0, 2}, {0, 1}}, slices.Collect(itertools.Take(tuples, 3)))
+
+	tuples = itertools.Product[int]()
+	assert.Equal(t, [][]int{{}}, slices.Collect(tuples))
+
+	tuples = itertools.Product(itertools.Of(1, 2), itertools.Empty[int](), itertools.Of(3))
+	assert.Equal(t, [][]int(nil), slices.Collect(tuples))
+
+	tuples = itertools.Product(itertools.Empty[int](), itertools.Of(1))
+	assert.Equal(t, [][]int(nil), slices.Collect(tuples))
+}
+
+func TestItertools_PowerSet(t *testing.T) {
+	subsets := itertools.PowerSet([]string{"a", "b", "c"})
+	assert.Equal(t, [][]string{{}, {"a"}, {"b"}, {"a", "b"}, {"c"}, {"a", "c"}, {"b", "c"}, {"a", "b", "c"}}, slices.Collect(subsets))
+
+	assert.Len(t, slices.Collect(itertools.PowerSet(make([]int, 10))), 1<<10)
+
+	subsets = itertools.PowerSet([]string(nil))
+	assert.Equal(t, [][]string{{}}, slices.Collect(subsets))
+}
+
+func TestItertools_Positions(t *testing.T) {
+	is := itertools.Positions(itertools.FromSlice(strings.Fields("a , b c , d ,")), func(s string) bool { return s == "," })
+	assert.Equal(t, []int{1, 4, 6}, slices.Collect(is))
+
+	is = itertools.Positions(IntRange(0, 5), func(i int) bool { return false })
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.Positions(itertools.Repeat(1), func(i int) bool { return true })
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(itertools.Take(is, 3)))
+
+	is = itertools.Positions(itertools.Empty[int](), func(i int) bool { return true })
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_WindowMax(t *testing.T) {
+	values := []int{1, 3, -1, -3, 5, 3, 6, 7}
+
+	is := itertools.WindowMax(itertools.FromSlice(values), 3)
+	assert.Equal(t, []int{3, 3, 5, 5, 6, 7}, slices.Collect(is))
+
+	is = itertools.WindowMax(itertools.FromSlice(values), uint(1))
+	assert.Equal(t, values, slices.Collect(is))
+
+	is = itertools.WindowMax(itertools.FromSlice([]int{5, 4, 3, 2, 1}), 2)
+	assert.Equal(t, []int{5, 4, 3, 2}, slices.Collect(is))
+
+	is = itertools.WindowMax(itertools.FromSlice(values), 10)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	assert.Panics(t, func() { itertools.WindowMax(itertools.Empty[int](), 0) })
+}
+
+func TestItertools_WindowMin(t *testing.T) {
+	values := []int{1, 3, -1, -3, 5, 3, 6, 7}
+
+	is := itertools.WindowMin(itertools.FromSlice(values), 3)
+	assert.Equal(t, []int{-1, -3, -3, -3, 3, 3}, slices.Collect(is))
+
+	is = itertools.WindowMin(itertools.FromSlice([]int{1, 2, 3, 4, 5}), 2)
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(is))
+
+	is = itertools.WindowMin(itertools.FromSlice([]int{2, 2, 2}), 2)
+	assert.Equal(t, []int{2, 2}, slices.Collect(is))
+
+	is = itertools.WindowMin(itertools.Empty[int](), 1)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	assert.Panics(t, func() { itertools.WindowMin(itertools.Empty[int](), -1) })
+}
+
+func TestItertools_BatchTimed(t *testing.T) {
+	batches := slices.Collect(itertools.BatchTimed(IntRange(0, 5), 2, time.Hour))
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4}}, batches)
+
+	i := -1
+	bursty := itertools.Take(itertools.WithFunc(func() int {
+		i++
+		if i == 3 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		return i
+	}), 5)
+	batches = slices.Collect(itertools.BatchTimed(bursty, 10, 20*time.Millisecond))
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4}}, batches)
+
+	batches = slices.Collect(itertools.Take(itertools.BatchTimed(itertools.Repeat(1), 2, time.Hour), 2))
+	assert.Equal(t, [][]int{{1, 1}, {1, 1}}, batches)
+
+	batches = slices.Collect(itertools.BatchTimed(itertools.Empty[int](), 2, time.Hour))
+	assert.Equal(t, [][]int(nil), batches)
+
+	assert.Panics(t, func() { itertools.BatchTimed(itertools.Empty[int](), 0, time.Hour) })
+}
+
+func TestItertools_DedupBy(t *testing.T) {
+	type reading struct {
+		sensor string
+		value  int
+	}
+	readings := []reading{{"a", 1}, {"a", 2}, {"b", 3}, {"a", 4}, {"a", 5}, {"c", 6}}
+
+	rs := itertools.DedupBy(itertools.FromSlice(readings), func(r reading) string { return r.sensor })
+	assert.Equal(t, []reading{{"a", 1}, {"b", 3}, {"a", 4}, {"c", 6}}, slices.Collect(rs))
+
+	is := itertools.DedupBy(itertools.FromSlice([]int{0, 0, 1, 1}), func(i int) int { return i })
+	assert.Equal(t, []int{0, 1}, slices.Collect(is))
+
+	is = itertools.DedupBy(itertools.Repeat(1), func(i int) int { return i })
+	assert.True(t, itertools.Any(is, func(i int) bool { return i == 1 }))
+
+	is = itertools.DedupBy(itertools.Empty[int](), func(i int) int { return i })
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_ForEachIndexed(t *testing.T) {
+	var indices []int
+	var values []string
+	itertools.ForEachIndexed(itertools.FromSlice([]string{"a", "b", "c"}), func(i int, s string) {
+		indices = append(indices, i)
+		values = append(values, s)
+	})
+	assert.Equal(t, []int{0, 1, 2}, indices)
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+
+	calls := 0
+	itertools.ForEachIndexed(itertools.Empty[string](), func(i int, s string) { calls++ })
+	assert.Equal(t, 0, calls)
+}
+
+func TestItertools_ForEachIndexed2(t *testing.T) {
+	var indices []int
+	var keys []string
+	var values []int
+	itertools.ForEachIndexed2(itertools.ZipShortest(itertools.Of("a", "b", "c"), itertools.Of(10, 20, 30)), func(i int, k string, v int) {
+		indices = append(indices, i)
+		keys = append(keys, k)
+		values = append(values, v)
+	})
+	assert.Equal(t, []int{0, 1, 2}, indices)
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+	assert.Equal(t, []int{10, 20, 30}, values)
+
+	calls := 0
+	itertools.ForEachIndexed2(Empty2[string, int](), func(i int, k string, v int) { calls++ })
+	assert.Equal(t, 0, calls)
+}
+
+func TestItertools_AppendTo(t *testing.T) {
+	buf := make([]int, 0, 10)
+	buf = itertools.AppendTo(buf, IntRange(0, 3))
+	buf = itertools.AppendTo(buf, IntRange(10, 12))
+	assert.Equal(t, []int{0, 1, 2, 10, 11}, buf)
+	assert.Equal(t, 10, cap(buf))
+
+	assert.Equal(t, []int{1}, itertools.AppendTo([]int{1}, itertools.Empty[int]()))
+	assert.Equal(t, []int(nil), itertools.AppendTo(nil, itertools.Empty[int]()))
+}
+
+func TestItertools_SplitBefore(t *testing.T) {
+	isHeader := func(s string) bool { return strings.HasPrefix(s, "#") }
+
+	groups := itertools.SplitBefore(itertools.FromSlice([]string{"#a", "1", "2", "#b", "#c", "3"}), isHeader)
+	assert.Equal(t, [][]string{{"#a", "1", "2"}, {"#b"}, {"#c", "3"}}, slices.Collect(groups))
+
+	groups = itertools.SplitBefore(itertools.FromSlice([]string{"0", "#a", "1"}), isHeader)
+	assert.Equal(t, [][]string{{"0"}, {"#a", "1"}}, slices.Collect(groups))
+
+	groups = itertools.SplitBefore(itertools.FromSlice([]string{"0", "#a", "1"}), isHeader)
+	assert.Equal(t, [][]string{{"0"}}, slices.Collect(itertools.Take(groups, 1)))
+
+	groups = itertools.SplitBefore(itertools.Empty[string](), isHeader)
+	assert.Equal(t, [][]string(nil), slices.Collect(groups))
+}
+
+func TestItertools_SplitAfter(t *testing.T) {
+	isTerminator := func(s string) bool { return s == ";" }
+
+	groups := itertools.SplitAfter(itertools.FromSlice([]string{"a", "b", ";", ";", "c"}), isTerminator)
+	assert.Equal(t, [][]string{{"a", "b", ";"}, {";"}, {"c"}}, slices.Collect(groups))
+
+	groups = itertools.SplitAfter(itertools.FromSlice([]string{"a", ";"}), isTerminator)
+	assert.Equal(t, [][]string{{"a", ";"}}, slices.Collect(groups))
+
+	groups = itertools.SplitAfter(itertools.FromSlice([]string{";", "a", ";"}), isTerminator)
+	assert.Equal(t, [][]string{{";"}}, slices.Collect(itertools.Take(groups, 1)))
+
+	groups = itertools.SplitAfter(itertools.Empty[string](), isTerminator)
+	assert.Equal(t, [][]string(nil), slices.Collect(groups))
+}
+
+func TestItertools_Demux(t *testing.T) {
+	var keys []int
+	var groups [][]int
+	for k, g := range itertools.Demux(IntRange(0, 10), func(i int) int { return i % 3 }) {
+		keys = append(keys, k)
+		groups = append(groups, slices.Collect(g))
+	}
+	assert.Equal(t, []int{0, 1, 2}, keys)
+	assert.Equal(t, [][]int{{0, 3, 6, 9}, {1, 4, 7}, {2, 5, 8}}, groups)
+
+	kgs := itertools.Demux(IntRange(0, 10), func(i int) int { return i % 3 })
+	assert.Equal(t, 1, len(maps.Collect(itertools.Take2(kgs, 1))))
+
+	kgs = itertools.Demux(itertools.Empty[int](), func(i int) int { return i % 3 })
+	assert.Equal(t, 0, len(maps.Collect(kgs)))
+}
+
+func TestItertools_DemuxChan(t *testing.T) {
+	var mu sync.Mutex
+	groups := make(map[int][]int)
+	itertools.DemuxChan(IntRange(0, 100), func(i int) int { return i % 3 }, 0, func(k int, ch <-chan int) {
+		vs := slices.Collect(itertools.FromChannel(ch))
+		mu.Lock()
+		defer mu.Unlock()
+		groups[k] = vs
+	})
+	require.Equal(t, 3, len(groups))
+	for k, vs := range groups {
+		assert.Equal(t, slices.Collect(itertools.Filter(IntRange(0, 100), func(i int) bool { return i%3 == k })), vs)
+	}
+
+	calls := 0
+	itertools.DemuxChan(itertools.Empty[int](), func(i int) int { return i }, 1, func(k int, ch <-chan int) { calls++ })
+	assert.Equal(t, 0, calls)
+}
+
+func TestItertools_Transpose(t *testing.T) {
+	cols := itertools.Transpose(itertools.Of([]int{1, 2, 3}, []int{4, 5, 6}))
+	assert.Equal(t, [][]int{{1, 4}, {2, 5}, {3, 6}}, slices.Collect(cols))
+
+	cols = itertools.Transpose(itertools.Of([]int{1, 2, 3}, []int{4, 5}, []int{6, 7, 8}))
+	assert.Equal(t, [][]int{{1, 4, 6}, {2, 5, 7}}, slices.Collect(cols))
+
+	cols = itertools.Transpose(itertools.Of([]int{1, 2, 3}, []int{}))
+	assert.Equal(t, [][]int(nil), slices.Collect(cols))
+
+	cols = itertools.Transpose(itertools.Of([]int{1, 2, 3}))
+	assert.Equal(t, [][]int{{1}, {2}}, slices.Collect(itertools.Take(cols, 2)))
+
+	cols = itertools.Transpose(itertools.Empty[[]int]())
+	assert.Equal(t, [][]int(nil), slices.Collect(cols))
+}
+
+func TestItertools_SumBy(t *testing.T) {
+	type order struct {
+		product string
+		price   float64
+	}
+	orders := []order{{"a", 1.5}, {"b", 2}, {"a", 3.25}}
+
+	assert.Equal(t, 6.75, itertools.SumBy(itertools.FromSlice(orders), func(o order) float64 { return o.price }))
+	assert.Equal(t, 3, itertools.SumBy(itertools.Of("a", "bb"), func(s string) int { return len(s) }))
+	assert.Equal(t, 0, itertools.SumBy(itertools.Empty[string](), func(s string) int { return len(s) }))
+}
+
+func TestItertools_CountBy(t *testing.T) {
+	counts := itertools.CountBy(itertools.Of("a", "bb", "cc", "ddd", "e"), func(s string) int { return len(s) })
+	assert.Equal(t, map[int]int{1: 2, 2: 2, 3: 1}, counts)
+
+	counts = itertools.CountBy(itertools.Empty[string](), func(s string) int { return len(s) })
+	assert.Equal(t, map[int]int{}, counts)
+}
+
+func TestItertools_RunningCountBy(t *testing.T) {
+	var values []string
+	var counts []int
+	for s, n := range itertools.RunningCountBy(itertools.Of("a", "bb", "cc", "d", "ee", "fff"), func(s string) int { return len(s) }) {
+		values = append(values, s)
+		counts = append(counts, n)
+	}
+	assert.Equal(t, []string{"a", "bb", "cc", "d", "ee", "fff"}, values)
+	assert.Equal(t, []int{1, 1, 2, 2, 3, 1}, counts)
+
+	kvs := itertools.RunningCountBy(itertools.Empty[string](), func(s string) int { return len(s) })
+	assert.Equal(t, map[string]int{}, maps.Collect(kvs))
+}
+
+func TestItertools_PartitionSeq(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	evens, odds := itertools.PartitionSeq(IntRange(0, 10), isEven)
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, slices.Collect(evens))
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, slices.Collect(odds))
+
+	evens, odds = itertools.PartitionSeq(IntRange(0, 10), isEven)
+	assert.Equal(t, []int{1, 3}, slices.Collect(itertools.Take(odds, 2)))
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, slices.Collect(evens))
+
+	n := 0
+	evens, _ = itertools.PartitionSeq(itertools.Counted(IntRange(0, 100), &n), isEven)
+	for range evens {
+		if n >= 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, n)
+
+	evens, odds = itertools.PartitionSeq(itertools.Empty[int](), isEven)
+	assert.Equal(t, []int(nil), slices.Collect(evens))
+	assert.Equal(t, []int(nil), slices.Collect(odds))
+}
+
+func TestItertools_Replace(t *testing.T) {
+	ss := itertools.Replace(itertools.Of("a", "N/A", "b", "N/A"), "N/A", "")
+	assert.Equal(t, []string{"a", "", "b", ""}, slices.Collect(ss))
+
+	is := itertools.Replace(IntRange(0, 3), 5, 0)
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+
+	is = itertools.Replace(itertools.Empty[int](), 5, 0)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_ReplaceFunc(t *testing.T) {
+	is := itertools.ReplaceFunc(itertools.Of(1, -2, 3, -4), func(i int) bool { return i < 0 }, func(i int) int { return -i })
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(is))
+
+	is = itertools.ReplaceFunc(itertools.Repeat(-1), func(i int) bool { return i < 0 }, func(i int) int { return 0 })
+	assert.Equal(t, []int{0, 0}, slices.Collect(itertools.Take(is, 2)))
+
+	is = itertools.ReplaceFunc(itertools.Empty[int](), func(i int) bool { return true }, func(i int) int { return 0 })
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_LongestRun(t *testing.T) {
+	v, n, ok := itertools.LongestRun(itertools.Of("a", "b", "b", "a", "a", "a", "c", "c"))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "a", v)
+	assert.Equal(t, 3, n)
+
+	v, n, ok = itertools.LongestRun(itertools.Of("a", "b", "b", "c", "c"))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "b", v)
+	assert.Equal(t, 2, n)
+
+	_, n, ok = itertools.LongestRun(itertools.Empty[string]())
+	assert.Equal(t, false, ok)
+	assert.Equal(t, 0, n)
+}
+
+func TestItertools_Normalize(t *testing.T) {
+	fs := itertools.Normalize(itertools.Of(10, 20, 15, 30))
+	assert.Equal(t, []float64{0, 0.5, 0.25, 1}, slices.Collect(fs))
+
+	fs = itertools.Normalize(itertools.Of(-1.0, 1.0))
+	assert.Equal(t, []float64{0, 1}, slices.Collect(fs))
+
+	fs = itertools.Normalize(itertools.Of(uint8(3), uint8(3)))
+	assert.Equal(t, []float64{0, 0}, slices.Collect(fs))
+
+	fs = itertools.Normalize(itertools.Empty[int]())
+	assert.Equal(t, []float64(nil), slices.Collect(fs))
+}
+
+func TestItertools_DropNth(t *testing.T) {
+	is := itertools.DropNth(IntRange(0, 10), 3)
+	assert.Equal(t, []int{0, 1, 3, 4, 6, 7, 9}, slices.Collect(is))
+
+	is = itertools.DropNth(IntRange(0, 5), uint(1))
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.DropNth(IntRange(0, 5), 10)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(is))
+
+	is = itertools.DropNth(itertools.Empty[int](), 2)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	assert.Panics(t, func() { itertools.DropNth(itertools.Empty[int](), 0) })
+}
+
+func TestItertools_Fuse(t *testing.T) {
+	is := itertools.Fuse(itertools.Of(0, 1, 2))
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.Fuse(itertools.Of(0, 1, 2))
+	for range is {
+		break
+	}
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.Fuse(itertools.Empty[int]())
+	assert.Equal(t, []int(nil), slices.Collect(is))
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_CollectCapped(t *testing.T) {
+	is, capped := itertools.CollectCapped(itertools.Repeat(1), 3)
+	assert.Equal(t, []int{1, 1, 1}, is)
+	assert.Equal(t, true, capped)
+
+	is, capped = itertools.CollectCapped(IntRange(0, 3), 3)
+	assert.Equal(t, []int{0, 1, 2}, is)
+	assert.Equal(t, false, capped)
+
+	is, capped = itertools.CollectCapped(IntRange(0, 3), 10)
+	assert.Equal(t, []int{0, 1, 2}, is)
+	assert.Equal(t, false, capped)
+
+	is, capped = itertools.CollectCapped(IntRange(0, 3), 0)
+	assert.Equal(t, []int(nil), is)
+	assert.Equal(t, true, capped)
+
+	is, capped = itertools.CollectCapped(itertools.Empty[int](), 0)
+	assert.Equal(t, []int(nil), is)
+	assert.Equal(t, false, capped)
+}
+
+func TestItertools_CollectKeysValues(t *testing.T) {
+	keys, values := itertools.CollectKeysValues(itertools.ZipShortest(itertools.Of("b", "a", "c"), IntRange(0, 5)))
+	assert.Equal(t, []string{"b", "a", "c"}, keys)
+	assert.Equal(t, []int{0, 1, 2}, values)
+
+	keys, values = itertools.CollectKeysValues(Empty2[string, int]())
+	assert.Equal(t, []string(nil), keys)
+	assert.Equal(t, []int(nil), values)
+}
+
+func TestItertools_UniqueWindow(t *testing.T) {
+	ss := itertools.UniqueWindow(itertools.Of("a", "b", "a", "c", "d", "a", "a"), 2)
+	assert.Equal(t, []string{"a", "b", "c", "d", "a"}, slices.Collect(ss))
+
+	ss = itertools.UniqueWindow(itertools.Of("a", "b", "a", "c", "d", "a", "a"), 10)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(ss))
+
+	ss = itertools.UniqueWindow(itertools.Of("a", "a", "b", "b"), 1)
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(ss))
+
+	ss = itertools.UniqueWindow(itertools.Of("a", "a"), 0)
+	assert.Equal(t, []string{"a", "a"}, slices.Collect(ss))
+
+	is := itertools.UniqueWindow(itertools.Cycle(IntRange(0, 3)), 2)
+	assert.Equal(t, []int{0, 1, 2, 0, 1}, slices.Collect(itertools.Take(is, 5)))
+
+	ss = itertools.UniqueWindow(itertools.Empty[string](), 2)
+	assert.Equal(t, []string(nil), slices.Collect(ss))
+}
+
+func TestItertools_UniqueLRU(t *testing.T) {
+	var evicted []string
+	ss := itertools.UniqueLRU(itertools.Of("a", "b", "a", "c", "a", "d", "b"), 2, func(s string) {
+		evicted = append(evicted, s)
+	})
+	assert.Equal(t, []string{"a", "b", "c", "d", "b"}, slices.Collect(ss))
+	assert.Equal(t, []string{"b", "c", "a"}, evicted)
+
+	ss = itertools.UniqueLRU(itertools.Of("a", "b", "a", "c", "d", "a"), 10, nil)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(ss))
+
+	ss = itertools.UniqueLRU(itertools.Of("a", "a"), 0, func(string) { t.Fail() })
+	assert.Equal(t, []string{"a", "a"}, slices.Collect(ss))
+
+	is := itertools.UniqueLRU(itertools.Cycle(IntRange(0, 3)), 2, nil)
+	assert.Equal(t, []int{0, 1, 2, 0, 1}, slices.Collect(itertools.Take(is, 5)))
+
+	ss = itertools.UniqueLRU(itertools.Empty[string](), 2, nil)
+	assert.Equal(t, []string(nil), slices.Collect(ss))
+}
+
+func TestItertools_WindowSum(t *testing.T) {
+	is := itertools.WindowSum(IntRange(0, 6), 3)
+	assert.Equal(t, []int{0 + 1 + 2, 1 + 2 + 3, 2 + 3 + 4, 3 + 4 + 5}, slices.Collect(is))
+
+	is = itertools.WindowSum(IntRange(0, 3), uint(1))
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(is))
+
+	fs := itertools.WindowSum(itertools.Of(0.5, 1.5, 2.5), 2)
+	assert.Equal(t, []float64{2, 4}, slices.Collect(fs))
+
+	fs = itertools.WindowSum(itertools.Of(1e16, 1.0, 1.0, 1.0), 1)
+	assert.Equal(t, []float64{1e16, 1, 1, 1}, slices.Collect(fs))
+
+	fs = itertools.WindowSum(itertools.Of(0.1, 1e16, 0.1, 0.1, 0.1, 0.1), 2)
+	assert.Equal(t, []float64{0.1 + 1e16, 1e16 + 0.1, 0.1 + 0.1, 0.1 + 0.1, 0.1 + 0.1}, slices.Collect(fs))
+
+	is = itertools.WindowSum(IntRange(0, 2), 3)
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	assert.Panics(t, func() { itertools.WindowSum(itertools.Empty[int](), 0) })
+}
+
+func TestItertools_CollectBytes(t *testing.T) {
+	bs := itertools.CollectBytes(itertools.Filter(itertools.FromBytes([]byte("a-b-c")), func(b byte) bool { return b != '-' }))
+	assert.Equal(t, []byte("abc"), bs)
+
+	bs = itertools.CollectBytes(itertools.Empty[byte]())
+	assert.Equal(t, 0, len(bs))
+}
+
+type countingWriter struct {
+	writes int
+	buf    []byte
+	limit  int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.limit > 0 && len(w.buf)+len(p) > w.limit {
+		n := w.limit - len(w.buf)
+		w.buf = append(w.buf, p[:n]...)
+		return n, io.ErrShortWrite
+	}
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+func TestItertools_WriteTo(t *testing.T) {
+	w := &countingWriter{}
+	n, err := itertools.WriteTo(itertools.RepeatN(byte('a'), 10000), w)
+	require.NoError(t, err)
+	assert.Equal(t, 10000, n)
+	assert.Equal(t, bytes.Repeat([]byte("a"), 10000), w.buf)
+	assert.Less(t, w.writes, 10)
+
+	w = &countingWriter{limit: 5}
+	n, err = itertools.WriteTo(itertools.FromBytes([]byte("hello, world")), w)
+	require.ErrorIs(t, err, io.ErrShortWrite)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []byte("hello"), w.buf)
+
+	w = &countingWriter{}
+	n, err = itertools.WriteTo(itertools.Empty[byte](), w)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 0, w.writes)
+}
+
+func TestItertools_Peekable(t *testing.T) {
+	p := itertools.NewPeekable(IntRange(0, 2))
+	defer p.Stop()
+
+	v, ok := p.Peek()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 0, v)
+
+	v, ok = p.Peek()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 0, v)
+
+	v, ok = p.Next()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 0, v)
+
+	v, ok = p.Next()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 1, v)
+
+	_, ok = p.Peek()
+	assert.Equal(t, false, ok)
+
+	_, ok = p.Next()
+	assert.Equal(t, false, ok)
+}
+
+func TestItertools_TakeWhilePeek(t *testing.T) {
+	isDigit := func(r rune) bool { return '0' <= r && r <= '9' }
+
+	p := itertools.NewPeekable(itertools.FromRunes("123+45"))
+	defer p.Stop()
+
+	assert.Equal(t, []rune("123"), itertools.TakeWhilePeek(p, isDigit))
+	assert.Equal(t, []rune(nil), itertools.TakeWhilePeek(p, isDigit))
+
+	r, ok := p.Next()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, '+', r)
+
+	assert.Equal(t, []rune("45"), itertools.TakeWhilePeek(p, isDigit))
+	_, ok = p.Peek()
+	assert.Equal(t, false, ok)
+}
+
+func TestItertools_DropWhilePeek(t *testing.T) {
+	isSpace := func(r rune) bool { return r == ' ' }
+
+	p := itertools.NewPeekable(itertools.FromRunes("   a "))
+	defer p.Stop()
+
+	assert.Equal(t, 3, itertools.DropWhilePeek(p, isSpace))
+	assert.Equal(t, 0, itertools.DropWhilePeek(p, isSpace))
+
+	r, ok := p.Next()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 'a', r)
+
+	assert.Equal(t, 1, itertools.DropWhilePeek(p, isSpace))
+	_, ok = p.Peek()
+	assert.Equal(t, false, ok)
+}
+
+func TestItertools_Identity(t *testing.T) {
+	assert.Equal(t, 1, itertools.Identity(1))
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(itertools.Map(itertools.Of("a", "b"), itertools.Identity[string])))
+}
+
+func TestItertools_Const(t *testing.T) {
+	ss := itertools.Map(IntRange(0, 3), itertools.Const[int]("a"))
+	assert.Equal(t, []string{"a", "a", "a"}, slices.Collect(ss))
+}
+
+func TestItertools_Not(t *testing.T) {
+	isEmpty := func(s string) bool { return s == "" }
+
+	ss := itertools.Filter(itertools.Of("a", "", "b", ""), itertools.Not(isEmpty))
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(ss))
+
+	assert.Equal(t, true, itertools.Not(isEmpty)("a"))
+	assert.Equal(t, false, itertools.Not(isEmpty)(""))
+}
+
+func TestItertools_CompareBy(t *testing.T) {
+	byLen := itertools.CompareBy(func(s string) int { return len(s) })
+	assert.Equal(t, -1, byLen("a", "bb"))
+	assert.Equal(t, 0, byLen("a", "b"))
+	assert.Equal(t, 1, byLen("aa", "b"))
+
+	a, ok := itertools.MinFunc(itertools.Of("ccc", "a", "bb"), byLen)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "a", a)
+}
+
+func TestItertools_ThenBy(t *testing.T) {
+	type person struct {
+		first, last string
+	}
+	byName := itertools.ThenBy(
+		itertools.CompareBy(func(p person) string { return p.last }),
+		itertools.CompareBy(func(p person) string { return p.first }),
+	)
+
+	people := []person{{"bob", "smith"}, {"alice", "smith"}, {"carol", "jones"}}
+	slices.SortFunc(people, byName)
+	assert.Equal(t, []person{{"carol", "jones"}, {"alice", "smith"}, {"bob", "smith"}}, people)
+	assert.True(t, itertools.IsSortedFunc(itertools.FromSlice(people), byName))
+
+	byLenThenReverse := itertools.ThenBy(
+		itertools.CompareBy(func(s string) int { return len(s) }),
+		func(a, b string) int { return strings.Compare(b, a) },
+	)
+	a, ok := itertools.MaxFunc(itertools.Of("ab", "b", "aa", "c"), byLenThenReverse)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "aa", a)
+}
+
+func TestItertools_Metered(t *testing.T) {
+	var indices []int
+	var values []string
+	ss := itertools.Metered(itertools.Of("a", "b", "c"), func(i int, s string) {
+		indices = append(indices, i)
+		values = append(values, s)
+	})
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(ss))
+	assert.Equal(t, []int{0, 1, 2}, indices)
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+
+	calls := 0
+	is := itertools.Metered(itertools.Repeat(1), func(int, int) { calls++ })
+	assert.True(t, itertools.Any(is, func(v int) bool { return v == 1 }))
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	assert.Equal(t, []int(nil), slices.Collect(itertools.Metered(itertools.Empty[int](), func(int, int) { calls++ })))
+	assert.Equal(t, 0, calls)
+}
+
+func TestItertools_Differences(t *testing.T) {
+	is := itertools.Differences(itertools.Of(1, 4, 9, 16, 25))
+	assert.Equal(t, []int{3, 5, 7, 9}, slices.Collect(is))
+
+	fs := itertools.Differences(itertools.Of(1.5, 1.0, 2.5))
+	assert.Equal(t, []float64{-0.5, 1.5}, slices.Collect(fs))
+
+	is = itertools.Differences(itertools.Of(1))
+	assert.Equal(t, []int(nil), slices.Collect(is))
+
+	is = itertools.Differences(itertools.Empty[int]())
+	assert.Equal(t, []int(nil), slices.Collect(is))
+}
+
+func TestItertools_CumulativeSum(t *testing.T) {
+	assert.Equal(t, []int{1, 3, 6, 10}, slices.Collect(itertools.CumulativeSum(IntRange(1, 5))))
+	assert.Equal(t, []float64{0.5, 2}, slices.Collect(itertools.CumulativeSum(itertools.Of(0.5, 1.5))))
+	assert.Equal(t, []int(nil), slices.Collect(itertools.CumulativeSum(itertools.Empty[int]())))
+}
+
+func TestItertools_CumulativeProduct(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 6, 24}, slices.Collect(itertools.CumulativeProduct(IntRange(1, 5))))
+	assert.Equal(t, []int(nil), slices.Collect(itertools.CumulativeProduct(itertools.Empty[int]())))
+}
+
+func TestItertools_CumulativeMax(t *testing.T) {
+	assert.Equal(t, []int{3, 3, 4, 4, 5}, slices.Collect(itertools.CumulativeMax(itertools.Of(3, 1, 4, 1, 5))))
+	assert.Equal(t, []string{"b", "b", "c"}, slices.Collect(itertools.CumulativeMax(itertools.Of("b", "a", "c"))))
+	assert.Equal(t, []int(nil), slices.Collect(itertools.CumulativeMax(itertools.Empty[int]())))
+}
+
+func TestItertools_CumulativeMin(t *testing.T) {
+	assert.Equal(t, []int{3, 1, 1, 1, 1}, slices.Collect(itertools.CumulativeMin(itertools.Of(3, 1, 4, 1, 5))))
+	assert.Equal(t, []int(nil), slices.Collect(itertools.CumulativeMin(itertools.Empty[int]())))
+}